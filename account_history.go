@@ -0,0 +1,165 @@
+package walletsdk
+
+import (
+	"fmt"
+
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/pkg/errors"
+)
+
+// AccountHistoryIterator iterates token transfer events of account from the newest to the oldest,
+// and fetches subsequent pages automatically.
+//
+// Pages are requested by the epoch number of the last fetched event instead of page number,
+// so that new transfers arrived during iterating do not cause duplicates or gaps.
+type AccountHistoryIterator struct {
+	rc      *RichClient
+	account types.Address
+	option  richtypes.AccountHistoryOption
+
+	buffer  []richtypes.TokenTransferEvent
+	current *richtypes.TokenTransferEvent
+
+	// cursor is the position of last fetched event, and skipInEpoch is the count of fetched events in the epoch of cursor
+	cursor      *richtypes.HistoryCursor
+	skipInEpoch uint
+	// seen contains events fetched in the epoch of cursor for removing duplicates
+	seen      map[string]struct{}
+	exhausted bool
+	err       error
+}
+
+// NewAccountHistoryIterator creates iterator of token transfer events of account, the default option is used if option is nil
+func (rc *RichClient) NewAccountHistoryIterator(account types.Address, option *richtypes.AccountHistoryOption) *AccountHistoryIterator {
+	it := &AccountHistoryIterator{
+		rc:      rc,
+		account: account,
+		seen:    make(map[string]struct{}),
+	}
+	if option != nil {
+		it.option = *option
+	}
+	if it.option.PageSize == 0 {
+		it.option.PageSize = richtypes.DefaultHistoryPageSize
+	}
+	if it.option.After != nil {
+		after := *it.option.After
+		it.cursor = &after
+	}
+	return it
+}
+
+// Next moves to the next event and returns true if it exists, it returns false when all events are iterated or error occurs
+func (it *AccountHistoryIterator) Next() bool {
+	for len(it.buffer) == 0 {
+		if it.err != nil || it.exhausted {
+			it.current = nil
+			return false
+		}
+		if err := it.fetch(); err != nil {
+			it.err = err
+		}
+	}
+
+	it.current = &it.buffer[0]
+	it.buffer = it.buffer[1:]
+	return true
+}
+
+// Event returns the current event, it is nil before Next is called or after Next returns false
+func (it *AccountHistoryIterator) Event() *richtypes.TokenTransferEvent {
+	return it.current
+}
+
+// Cursor returns cursor of the current event, it could be used as AccountHistoryOption.After for continuing iterating later
+func (it *AccountHistoryIterator) Cursor() *richtypes.HistoryCursor {
+	if it.current == nil {
+		return nil
+	}
+	cursor := it.current.Cursor()
+	return &cursor
+}
+
+// Err returns the error occurred during iterating
+func (it *AccountHistoryIterator) Err() error {
+	return it.err
+}
+
+// fetch requests the next page and appends matched events to buffer
+func (it *AccountHistoryIterator) fetch() error {
+	params := make(map[string]interface{})
+	params["limit"] = it.option.PageSize
+	params["skip"] = 0
+	if it.cursor != nil {
+		params["maxEpochNumber"] = it.cursor.EpochNumber
+		params["skip"] = it.skipInEpoch
+	}
+	if !it.option.StartTime.IsZero() {
+		params["minTimestamp"] = it.option.StartTime.Unix()
+	}
+	if !it.option.EndTime.IsZero() {
+		params["maxTimestamp"] = it.option.EndTime.Unix()
+	}
+
+	transferType := it.option.TransferType
+	if transferType == "" {
+		transferType = richtypes.GetTransferType(it.option.TokenIdentifier)
+	}
+	list, err := it.rc.getAccountTokenTransfers(it.account, it.option.TokenIdentifier, transferType, params)
+	if err != nil {
+		return errors.Wrapf(err, "get token transfers of %v by params %+v error", it.account, params)
+	}
+
+	beforeStart := 0
+	for _, event := range list.List {
+		cursor := event.Cursor()
+		if it.cursor == nil || cursor.EpochNumber != it.cursor.EpochNumber {
+			it.skipInEpoch = 0
+			it.seen = make(map[string]struct{})
+		}
+		it.cursor = &cursor
+		it.skipInEpoch++
+
+		key := fmt.Sprintf("%v-%v", event.TransactionHash, event.TransactionLogIndex)
+		if _, ok := it.seen[key]; ok {
+			continue
+		}
+		it.seen[key] = struct{}{}
+
+		if it.option.After != nil && cursor.Compare(*it.option.After) >= 0 {
+			continue
+		}
+
+		timestamp := event.Timestamp.Time()
+		if !it.option.StartTime.IsZero() && timestamp.Before(it.option.StartTime) {
+			beforeStart++
+			continue
+		}
+		if !it.option.EndTime.IsZero() && timestamp.After(it.option.EndTime) {
+			continue
+		}
+		if !it.matchDirection(&event) || it.rc.isHiddenTokenTransferEvent(&event) {
+			continue
+		}
+		it.buffer = append(it.buffer, event)
+	}
+
+	// skip and exhaustion are counted on raw scan results, which include hidden flagged events;
+	// the history is ordered by time, so the following pages are all before start time
+	if uint(len(list.List)) < it.option.PageSize || (len(list.List) > 0 && beforeStart == len(list.List)) {
+		it.exhausted = true
+	}
+	return nil
+}
+
+func (it *AccountHistoryIterator) matchDirection(event *richtypes.TokenTransferEvent) bool {
+	account := it.account.MustGetCommonAddress()
+	switch it.option.Direction {
+	case richtypes.HistoryDirectionIn:
+		return event.To != nil && event.To.MustGetCommonAddress() == account
+	case richtypes.HistoryDirectionOut:
+		return event.From.MustGetCommonAddress() == account
+	}
+	return true
+}
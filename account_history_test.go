@@ -0,0 +1,257 @@
+package walletsdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	sdk "github.com/Conflux-Chain/go-conflux-sdk"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/mock"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+)
+
+// historyClientMock mocks methods used by getAccountTokenTransfers and NewTxDictConverter
+type historyClientMock struct {
+	sdk.ClientOperator
+}
+
+func (c *historyClientMock) GetNetworkID() (uint32, error) {
+	return cfxaddress.NetowrkTypeMainnetID, nil
+}
+
+func (c *historyClientMock) BatchGetTxByHashes(txhashes []types.Hash) (map[types.Hash]*types.Transaction, error) {
+	return make(map[types.Hash]*types.Transaction), nil
+}
+
+func (c *historyClientMock) BatchGetBlockConfirmationRisk(blockhashes []types.Hash) (map[types.Hash]*big.Float, error) {
+	return make(map[types.Hash]*big.Float), nil
+}
+
+// historyServerMock serves transactions and token transfers ordered by epoch number descendingly with skip, limit and maxEpochNumber
+type historyServerMock struct {
+	txs       []richtypes.Transaction
+	transfers map[richtypes.TransferType][]richtypes.TokenTransferEvent
+}
+
+func (s *historyServerMock) Get(rawURL string) (*http.Response, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	query := u.Query()
+	skip, _ := strconv.Atoi(query.Get("skip"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	// marshal by map because the empty ContractCreated could not be unmarshaled
+	records := make([]map[string]interface{}, 0)
+	if u.Path == tokenTransferListPath {
+		for _, tte := range s.transfers[richtypes.TransferType(query.Get("transferType"))] {
+			records = append(records, map[string]interface{}{
+				"transactionHash":     tte.TransactionHash,
+				"transactionLogIndex": tte.TransactionLogIndex,
+				"transactionIndex":    tte.TransactionIndex,
+				"epochNumber":         tte.EpochNumber,
+				"address":             tte.ContractAddress,
+				"from":                tte.From,
+				"to":                  tte.To,
+				"value":               tte.Value,
+				"tokenId":             tte.TokenID,
+				"timestamp":           int64(tte.Timestamp),
+			})
+		}
+	} else {
+		for _, tx := range s.txs {
+			records = append(records, map[string]interface{}{
+				"hash":             tx.Hash,
+				"transactionIndex": tx.TransactionIndex,
+				"epochNumber":      tx.EpochNumber,
+				"from":             tx.From,
+				"to":               tx.To,
+				"value":            tx.Value,
+				"timestamp":        int64(tx.Timestamp),
+			})
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i]["epochNumber"].(uint64) > records[j]["epochNumber"].(uint64)
+	})
+	matched := make([]map[string]interface{}, 0)
+	for _, record := range records {
+		if maxEpoch := query.Get("maxEpochNumber"); maxEpoch != "" {
+			if max, _ := strconv.ParseUint(maxEpoch, 10, 64); record["epochNumber"].(uint64) > max {
+				continue
+			}
+		}
+		matched = append(matched, record)
+	}
+
+	page := make([]map[string]interface{}, 0)
+	for i := skip; i < len(matched) && i < skip+limit; i++ {
+		page = append(page, matched[i])
+	}
+
+	body, _ := json.Marshal(richtypes.ErrorResponse{Result: map[string]interface{}{"total": len(matched), "list": page}})
+	return &http.Response{Body: ioutil.NopCloser(bytes.NewReader(body))}, nil
+}
+
+func TestAccountHistoryIterator(t *testing.T) {
+	account := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+	other := cfxaddress.MustNewFromHex("0x1ead8630345121d19ee3604128e5dc54b36e8ea6", cfxaddress.NetowrkTypeMainnetID)
+
+	newTx := func(epoch, index uint64, from, to types.Address) richtypes.Transaction {
+		return richtypes.Transaction{
+			Hash:             types.Hash("0x" + strconv.FormatUint(epoch*100+index, 16)),
+			TransactionIndex: index,
+			EpochNumber:      epoch,
+			From:             from,
+			To:               &to,
+			Value:            "1",
+			Timestamp:        richtypes.JSONTime(1600000000 + epoch),
+		}
+	}
+
+	server := &historyServerMock{}
+	// two transactions in epoch 5 are split by pages
+	for _, tx := range []richtypes.Transaction{
+		newTx(6, 0, account, other),
+		newTx(5, 1, other, account),
+		newTx(5, 0, account, other),
+		newTx(4, 0, other, account),
+		newTx(3, 0, account, other),
+	} {
+		server.txs = append(server.txs, tx)
+	}
+
+	rc := &RichClient{
+		cfxScanBackend: &scanServer{Scheme: "http", Address: "test", HTTPRequester: server},
+		client:         &historyClientMock{},
+	}
+
+	if _, err := rc.GetAccountTokenTransfers(account, nil, 0, 10); err == nil {
+		t.Errorf("expect error for page number 0")
+	}
+
+	it := rc.NewAccountHistoryIterator(account, &richtypes.AccountHistoryOption{PageSize: 2})
+	epochs := make([]uint64, 0)
+	for it.Next() {
+		epochs = append(epochs, it.Event().EpochNumber)
+		// a new transaction arrives during iterating
+		if len(epochs) == 1 {
+			server.txs = append(server.txs, newTx(7, 0, other, account))
+		}
+	}
+	if it.Err() != nil {
+		t.Fatal(it.Err())
+	}
+	if expect := []uint64{6, 5, 5, 4, 3}; !equalUint64s(epochs, expect) {
+		t.Errorf("expect epochs %v without duplicates or gaps, actual: %v", expect, epochs)
+	}
+
+	// only incoming transfers before the cursor of epoch 5 and in time range are iterated
+	it = rc.NewAccountHistoryIterator(account, &richtypes.AccountHistoryOption{
+		PageSize:  2,
+		Direction: richtypes.HistoryDirectionIn,
+		StartTime: time.Unix(1600000004, 0),
+		After:     &richtypes.HistoryCursor{EpochNumber: 5, TransactionIndex: 1},
+	})
+	epochs = epochs[:0]
+	for it.Next() {
+		epochs = append(epochs, it.Event().EpochNumber)
+	}
+	if it.Err() != nil {
+		t.Fatal(it.Err())
+	}
+	if expect := []uint64{4}; !equalUint64s(epochs, expect) {
+		t.Errorf("expect epochs %v, actual: %v", expect, epochs)
+	}
+}
+
+func TestAccountHistoryIteratorHideFlagged(t *testing.T) {
+	account := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+	other := cfxaddress.MustNewFromHex("0x1ead8630345121d19ee3604128e5dc54b36e8ea6", cfxaddress.NetowrkTypeMainnetID)
+	allowed := cfxaddress.MustNewFromHex("0x8a5c2e6b2f1d6e0ec5b9a2b7d0c3f4e5a6b7c8d9", cfxaddress.NetowrkTypeMainnetID)
+	denied := cfxaddress.MustNewFromHex("0x8b6d3f7c3a2e7f1fd6cab3c8e1d4a5f6b7c8d9ea", cfxaddress.NetowrkTypeMainnetID)
+
+	checker := NewTokenReputationChecker(&richtypes.TokenReputationOption{HideFlagged: true})
+	if err := checker.Allow(allowed.MustGetCommonAddress().Hex()); err != nil {
+		t.Fatal(err)
+	}
+	if err := checker.Deny(denied.MustGetCommonAddress().Hex()); err != nil {
+		t.Fatal(err)
+	}
+
+	newTransfer := func(epoch uint64, token types.Address) richtypes.TokenTransferEvent {
+		return richtypes.TokenTransferEvent{
+			TransactionHash: types.Hash("0x" + strconv.FormatUint(epoch, 16)),
+			EpochNumber:     epoch,
+			ContractAddress: &token,
+			From:            other,
+			To:              &account,
+			Value:           "1",
+			Timestamp:       richtypes.JSONTime(1600000000 + epoch),
+		}
+	}
+
+	// the first page only has one event left after hiding the flagged one
+	server := &historyServerMock{transfers: map[richtypes.TransferType][]richtypes.TokenTransferEvent{
+		richtypes.TransferTypeERC20: {
+			newTransfer(6, allowed),
+			newTransfer(5, denied),
+			newTransfer(4, denied),
+			newTransfer(3, allowed),
+		},
+	}}
+
+	var contractManagerRequester mock.HttpClientMock
+	rspBody, _ := json.Marshal(richtypes.ErrorResponse{Result: richtypes.Token{TokenName: "Token", TokenSymbol: "TK", TokenDecimal: 18}})
+	contractManagerRequester.SetHandler("", string(rspBody))
+
+	rc := &RichClient{
+		cfxScanBackend:  &scanServer{Scheme: "http", Address: "test", HTTPRequester: server},
+		contractManager: &scanServer{Scheme: "http", Address: "test", HTTPRequester: &contractManagerRequester},
+		client:          &historyClientMock{},
+		tokenReputation: checker,
+	}
+
+	list, err := rc.GetAccountTokenTransfers(account, &allowed, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.List) != 1 || list.List[0].EpochNumber != 6 {
+		t.Errorf("expect flagged transfer event hidden, actual: %+v", list.List)
+	}
+
+	it := rc.NewAccountHistoryIterator(account, &richtypes.AccountHistoryOption{PageSize: 2, TransferType: richtypes.TransferTypeERC20})
+	epochs := make([]uint64, 0)
+	for it.Next() {
+		epochs = append(epochs, it.Event().EpochNumber)
+	}
+	if it.Err() != nil {
+		t.Fatal(it.Err())
+	}
+	if expect := []uint64{6, 3}; !equalUint64s(epochs, expect) {
+		t.Errorf("expect epochs %v without flagged ones, actual: %v", expect, epochs)
+	}
+}
+
+func equalUint64s(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,137 @@
+package walletsdk
+
+import (
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/pkg/errors"
+)
+
+// AccountActivityFeed merges CFX transactions and token transfers of all types of account into one feed
+// ordered from the newest to the oldest, and each item is converted to TxDict.
+type AccountActivityFeed struct {
+	converter *TxDictConverter
+	streams   []*AccountHistoryIterator
+	// heads contains the current event of each stream, it is nil if the stream is not started or exhausted
+	heads   []*richtypes.TokenTransferEvent
+	started bool
+	current *richtypes.ActivityItem
+	err     error
+	// pendingErr is the error occurred after the current item is converted, it is reported by the following Next
+	pendingErr error
+}
+
+// NewAccountActivityFeed creates activity feed of account, the default option is used if option is nil
+func (rc *RichClient) NewAccountActivityFeed(account types.Address, option *richtypes.ActivityFeedOption) (*AccountActivityFeed, error) {
+	converter, err := NewTxDictConverter(rc)
+	if err != nil {
+		return nil, errors.Wrap(err, "create TxDict converter error")
+	}
+
+	if option == nil {
+		option = &richtypes.ActivityFeedOption{}
+	}
+	transferTypes := option.TransferTypes
+	if len(transferTypes) == 0 {
+		transferTypes = richtypes.AllTransferTypes
+	}
+
+	feed := &AccountActivityFeed{
+		converter: converter,
+		streams:   make([]*AccountHistoryIterator, len(transferTypes)),
+		heads:     make([]*richtypes.TokenTransferEvent, len(transferTypes)),
+	}
+	for i, transferType := range transferTypes {
+		feed.streams[i] = rc.NewAccountHistoryIterator(account, &richtypes.AccountHistoryOption{
+			TransferType: transferType,
+			PageSize:     option.PageSize,
+			StartTime:    option.StartTime,
+			EndTime:      option.EndTime,
+			Direction:    option.Direction,
+		})
+	}
+	return feed, nil
+}
+
+// Next moves to the next item and returns true if it exists, it returns false when all items are iterated or error occurs
+func (af *AccountActivityFeed) Next() bool {
+	af.current = nil
+	if af.pendingErr != nil {
+		af.err, af.pendingErr = af.pendingErr, nil
+	}
+	if af.err != nil {
+		return false
+	}
+
+	if !af.started {
+		for i := range af.streams {
+			if !af.advance(i) {
+				return false
+			}
+		}
+		af.started = true
+	}
+
+	// pick the newest head, the stream in front wins for the same position
+	newest := -1
+	for i, head := range af.heads {
+		if head != nil && (newest < 0 || head.Cursor().Compare(af.heads[newest].Cursor()) > 0) {
+			newest = i
+		}
+	}
+	if newest < 0 {
+		return false
+	}
+
+	event := *af.heads[newest]
+	txDict, err := af.converter.ConvertByTokenTransferEvent(&event)
+	if err != nil {
+		af.err = errors.Wrapf(err, "convert token transfer event %+v to TxDict error", event)
+		return false
+	}
+	if !af.advance(newest) {
+		// keep the converted item and report the error by the following Next
+		af.err, af.pendingErr = nil, af.err
+	}
+
+	af.current = &richtypes.ActivityItem{Event: event, TxDict: txDict}
+	return true
+}
+
+// Item returns the current item, it is nil before Next is called or after Next returns false
+func (af *AccountActivityFeed) Item() *richtypes.ActivityItem {
+	return af.current
+}
+
+// NextPage returns at most pageSize items, it returns empty slice when all items are iterated.
+// The error occurred after some items are collected is returned by the following NextPage, so that the items are not lost.
+func (af *AccountActivityFeed) NextPage(pageSize int) ([]richtypes.ActivityItem, error) {
+	items := make([]richtypes.ActivityItem, 0, pageSize)
+	for len(items) < pageSize && af.Next() {
+		items = append(items, *af.current)
+	}
+	if af.err != nil && len(items) > 0 {
+		af.err, af.pendingErr = nil, af.err
+	}
+	return items, af.err
+}
+
+// Err returns the error occurred during iterating
+func (af *AccountActivityFeed) Err() error {
+	return af.err
+}
+
+// advance moves stream i to the next event, it returns false if error occurs
+func (af *AccountActivityFeed) advance(i int) bool {
+	stream := af.streams[i]
+	if stream.Next() {
+		af.heads[i] = stream.Event()
+		return true
+	}
+
+	af.heads[i] = nil
+	if err := stream.Err(); err != nil {
+		af.err = errors.Wrapf(err, "iterate %v transfers error", stream.option.TransferType)
+		return false
+	}
+	return true
+}
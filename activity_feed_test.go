@@ -0,0 +1,144 @@
+package walletsdk
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/mock"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+)
+
+func TestAccountActivityFeed(t *testing.T) {
+	account := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+	other := cfxaddress.MustNewFromHex("0x1ead8630345121d19ee3604128e5dc54b36e8ea6", cfxaddress.NetowrkTypeMainnetID)
+	token := cfxaddress.MustNewFromHex("0x8b8689c7f3014a4d86e4d1d0daaf74a47f5e0f27", cfxaddress.NetowrkTypeMainnetID)
+	nft := cfxaddress.MustNewFromHex("0x8d545118d91c027c805c552f63a5c00a20ae6aca", cfxaddress.NetowrkTypeMainnetID)
+
+	server := &historyServerMock{
+		txs: []richtypes.Transaction{
+			{Hash: types.Hash("0x01"), EpochNumber: 5, From: account, To: &other, Value: "100", Timestamp: 1600000005},
+			{Hash: types.Hash("0x02"), EpochNumber: 2, From: other, To: &account, Value: "200", Timestamp: 1600000002},
+		},
+		transfers: map[richtypes.TransferType][]richtypes.TokenTransferEvent{
+			richtypes.TransferTypeERC20: {
+				{TransactionHash: types.Hash("0x03"), EpochNumber: 4, ContractAddress: &token, From: other, To: &account, Value: "300", Timestamp: 1600000004},
+			},
+			richtypes.TransferTypeERC721: {
+				{TransactionHash: types.Hash("0x04"), EpochNumber: 3, ContractAddress: &nft, From: account, To: &other, TokenID: "7", Timestamp: 1600000003},
+			},
+		},
+	}
+
+	var contractManagerRequester mock.HttpClientMock
+	rspBody, _ := json.Marshal(richtypes.ErrorResponse{Result: richtypes.Token{TokenName: "Token", TokenSymbol: "TK", TokenDecimal: 18}})
+	contractManagerRequester.SetHandler("", string(rspBody))
+
+	rc := &RichClient{
+		cfxScanBackend:  &scanServer{Scheme: "http", Address: "test", HTTPRequester: server},
+		contractManager: &scanServer{Scheme: "http", Address: "test", HTTPRequester: &contractManagerRequester},
+		client:          &historyClientMock{},
+	}
+
+	feed, err := rc.NewAccountActivityFeed(account, &richtypes.ActivityFeedOption{PageSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := feed.NextPage(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rest, err := feed.NextPage(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := append(page, rest...)
+
+	expect := []types.Hash{"0x01", "0x03", "0x04", "0x02"}
+	if len(page) != 3 || len(items) != len(expect) {
+		t.Fatalf("expect pages of 3 and 1 items, actual: %v and %v", len(page), len(rest))
+	}
+	for i, item := range items {
+		if item.Event.TransactionHash != expect[i] || item.TxDict == nil || item.TxDict.TxHash != expect[i] {
+			t.Errorf("expect item %v of tx %v, actual: %+v", i, expect[i], item)
+		}
+	}
+
+	nftUnit := items[2].TxDict.Outputs[0]
+	if items[2].Event.TransferType != richtypes.TransferTypeERC721 || nftUnit.TokenId.Cmp(big.NewInt(7)) != 0 || nftUnit.Value.Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("expect erc721 token 7 transferred, actual: %+v", nftUnit)
+	}
+}
+
+// failingHistoryServer fails requests of pages except the first one
+type failingHistoryServer struct {
+	historyServerMock
+}
+
+func (s *failingHistoryServer) Get(rawURL string) (*http.Response, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if skip := u.Query().Get("skip"); skip != "" && skip != "0" {
+		return nil, errors.New("server error")
+	}
+	return s.historyServerMock.Get(rawURL)
+}
+
+func TestAccountActivityFeedKeepItemOnError(t *testing.T) {
+	account := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+	other := cfxaddress.MustNewFromHex("0x1ead8630345121d19ee3604128e5dc54b36e8ea6", cfxaddress.NetowrkTypeMainnetID)
+
+	server := &failingHistoryServer{historyServerMock{
+		txs: []richtypes.Transaction{
+			{Hash: types.Hash("0x01"), EpochNumber: 5, From: account, To: &other, Value: "100", Timestamp: 1600000005},
+			{Hash: types.Hash("0x02"), EpochNumber: 2, From: other, To: &account, Value: "200", Timestamp: 1600000002},
+		},
+	}}
+	rc := &RichClient{
+		cfxScanBackend: &scanServer{Scheme: "http", Address: "test", HTTPRequester: server},
+		client:         &historyClientMock{},
+	}
+
+	feed, err := rc.NewAccountActivityFeed(account, &richtypes.ActivityFeedOption{
+		TransferTypes: []richtypes.TransferType{richtypes.TransferTypeCFX},
+		PageSize:      1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the second page fails after the first item is converted
+	if !feed.Next() || feed.Item() == nil || feed.Item().Event.TransactionHash != types.Hash("0x01") {
+		t.Fatalf("expect the first item returned, actual: %+v, error: %v", feed.Item(), feed.Err())
+	}
+	if feed.Err() != nil {
+		t.Fatalf("expect error not reported with the item, actual: %v", feed.Err())
+	}
+	if feed.Next() || feed.Err() == nil {
+		t.Fatalf("expect error reported by the following Next")
+	}
+
+	// the collected items are returned without error, which is returned by the following NextPage
+	feed, err = rc.NewAccountActivityFeed(account, &richtypes.ActivityFeedOption{
+		TransferTypes: []richtypes.TransferType{richtypes.TransferTypeCFX},
+		PageSize:      1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	items, err := feed.NextPage(10)
+	if err != nil || len(items) != 1 || items[0].Event.TransactionHash != types.Hash("0x01") {
+		t.Fatalf("expect the first item returned without error, actual: %+v, error: %v", items, err)
+	}
+	if items, err = feed.NextPage(10); err == nil || len(items) != 0 {
+		t.Fatalf("expect error reported by the following NextPage, actual: %+v", items)
+	}
+}
@@ -0,0 +1,172 @@
+package walletsdk
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AddressFilter is a set of addresses and token identifiers for filtering transactions,
+// addresses are keyed by hex address so that the same account of different networks is matched.
+type AddressFilter struct {
+	mutex     sync.RWMutex
+	addresses map[common.Address]struct{}
+	// tokens is nil for all tokens, the zero address key is for CFX
+	tokens map[common.Address]struct{}
+}
+
+// NewAddressFilter creates AddressFilter with addresses in hex or base32 format
+func NewAddressFilter(addresses ...string) (*AddressFilter, error) {
+	af := &AddressFilter{addresses: make(map[common.Address]struct{}, len(addresses))}
+	if err := af.AddAddresses(addresses...); err != nil {
+		return nil, err
+	}
+	return af, nil
+}
+
+// AddAddresses adds addresses in hex or base32 format
+func (af *AddressFilter) AddAddresses(addresses ...string) error {
+	parsed := make([]common.Address, len(addresses))
+	for i, address := range addresses {
+		commonAddress, err := parseFilterAddress(address)
+		if err != nil {
+			return err
+		}
+		parsed[i] = commonAddress
+	}
+
+	af.mutex.Lock()
+	defer af.mutex.Unlock()
+	for _, address := range parsed {
+		af.addresses[address] = struct{}{}
+	}
+	return nil
+}
+
+// RemoveAddresses removes addresses in hex or base32 format
+func (af *AddressFilter) RemoveAddresses(addresses ...string) error {
+	af.mutex.Lock()
+	defer af.mutex.Unlock()
+
+	for _, address := range addresses {
+		commonAddress, err := parseFilterAddress(address)
+		if err != nil {
+			return err
+		}
+		delete(af.addresses, commonAddress)
+	}
+	return nil
+}
+
+// SetTokens limits the filter to token identifiers, nil identifier means CFX, and all tokens are matched if tokens is empty
+func (af *AddressFilter) SetTokens(tokenIdentifiers ...*types.Address) {
+	af.mutex.Lock()
+	defer af.mutex.Unlock()
+
+	if len(tokenIdentifiers) == 0 {
+		af.tokens = nil
+		return
+	}
+
+	af.tokens = make(map[common.Address]struct{}, len(tokenIdentifiers))
+	for _, token := range tokenIdentifiers {
+		af.tokens[getTokenKey(token)] = struct{}{}
+	}
+}
+
+// Len returns the number of addresses
+func (af *AddressFilter) Len() int {
+	af.mutex.RLock()
+	defer af.mutex.RUnlock()
+	return len(af.addresses)
+}
+
+// ContainsAddress returns true if address is in the filter
+func (af *AddressFilter) ContainsAddress(address *types.Address) bool {
+	if address == nil {
+		return false
+	}
+
+	af.mutex.RLock()
+	defer af.mutex.RUnlock()
+	_, ok := af.addresses[address.MustGetCommonAddress()]
+	return ok
+}
+
+// ContainsToken returns true if token identifier is in the filter, nil identifier means CFX
+func (af *AddressFilter) ContainsToken(tokenIdentifier *types.Address) bool {
+	af.mutex.RLock()
+	defer af.mutex.RUnlock()
+
+	if af.tokens == nil {
+		return true
+	}
+	_, ok := af.tokens[getTokenKey(tokenIdentifier)]
+	return ok
+}
+
+// mayBeTouchedBy returns false if the transaction is a transfer between user accounts not in filter,
+// so that it is skipped without requesting receipt.
+func (af *AddressFilter) mayBeTouchedBy(tx *types.Transaction) bool {
+	if af.ContainsAddress(&tx.From) || af.ContainsAddress(tx.To) {
+		return true
+	}
+	return tx.To == nil || tx.To.GetAddressType() != cfxaddress.AddressTypeUser
+}
+
+// isTouchedBy returns true if any CFX or token unit of txDict is from or to addresses in filter
+func (af *AddressFilter) isTouchedBy(txDict *richtypes.TxDict) bool {
+	for _, units := range [][]richtypes.TxUnit{txDict.Inputs, txDict.Outputs} {
+		for i := range units {
+			if af.containsUnit(&units[i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// containsUnit returns true if both address and token of unit are in the filter, the EVM address of eSpace unit is matched too
+func (af *AddressFilter) containsUnit(unit *richtypes.TxUnit) bool {
+	address, ok := unit.GetCommonAddress()
+	if !ok {
+		return false
+	}
+
+	af.mutex.RLock()
+	defer af.mutex.RUnlock()
+
+	if _, ok := af.addresses[address]; !ok {
+		return false
+	}
+	if af.tokens == nil {
+		return true
+	}
+	_, ok = af.tokens[unit.GetTokenCommonAddress()]
+	return ok
+}
+
+func parseFilterAddress(address string) (common.Address, error) {
+	if common.IsHexAddress(address) && strings.HasPrefix(strings.ToLower(address), "0x") {
+		return common.HexToAddress(address), nil
+	}
+
+	cfxAddress, err := cfxaddress.NewFromBase32(address)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid address %v, it should be hex or base32 format", address)
+	}
+	return cfxAddress.MustGetCommonAddress(), nil
+}
+
+// getTokenKey returns the hex address of token identifier, it is the zero address for CFX
+func getTokenKey(tokenIdentifier *types.Address) common.Address {
+	if tokenIdentifier == nil {
+		return common.Address{}
+	}
+	return tokenIdentifier.MustGetCommonAddress()
+}
@@ -0,0 +1,145 @@
+package walletsdk
+
+import (
+	"math/big"
+	"testing"
+
+	sdk "github.com/Conflux-Chain/go-conflux-sdk"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// receiptClientMock mocks methods used by ConvertByTransaction
+type receiptClientMock struct {
+	sdk.ClientOperator
+	receipt *types.TransactionReceipt
+}
+
+func (c *receiptClientMock) GetNetworkID() (uint32, error) {
+	return cfxaddress.NetowrkTypeMainnetID, nil
+}
+
+func (c *receiptClientMock) GetTransactionReceipt(txHash types.Hash) (*types.TransactionReceipt, error) {
+	return c.receipt, nil
+}
+
+func TestFilterMovements(t *testing.T) {
+	watched := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+	other := cfxaddress.MustNewFromHex("0x160ebef20c1f739957bf9eecd040bce699cc42c6", cfxaddress.NetowrkTypeMainnetID)
+	token := cfxaddress.MustNewFromHex("0x8e2f2e68eb75bb8b18caafe9607242d4748f8d98", cfxaddress.NetowrkTypeMainnetID)
+
+	// the base32 address of testnet matches the same hex address of mainnet
+	filter, err := NewAddressFilter(cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetworkTypeTestnetID).String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txDict := richtypes.TxDict{}
+	txDict.Inputs = []richtypes.TxUnit{
+		{Value: big.NewInt(1), Address: &other},
+		{Value: big.NewInt(2), Address: &other, TokenIdentifier: &token},
+	}
+	txDict.Outputs = []richtypes.TxUnit{
+		{Value: big.NewInt(1), Address: &watched},
+		{Value: big.NewInt(2), Address: &watched, TokenIdentifier: &token},
+	}
+
+	movements := FilterMovements(&txDict, filter)
+	if len(movements) != 2 || movements[0].Direction != richtypes.MovementIn {
+		t.Fatalf("expect 2 incoming movements, actual: %+v", movements)
+	}
+
+	filter.SetTokens(&token)
+	movements = FilterMovements(&txDict, filter)
+	if len(movements) != 1 || movements[0].TokenIdentifier == nil {
+		t.Fatalf("expect 1 token movement, actual: %+v", movements)
+	}
+
+	if _, err := NewAddressFilter("invalid"); err == nil {
+		t.Fatalf("expect error for invalid address")
+	}
+}
+
+func TestFilterMovementsOfFailedTransaction(t *testing.T) {
+	watched := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+	other := cfxaddress.MustNewFromHex("0x160ebef20c1f739957bf9eecd040bce699cc42c6", cfxaddress.NetowrkTypeMainnetID)
+	contract := cfxaddress.MustNewFromHex("0x8e2f2e68eb75bb8b18caafe9607242d4748f8d98", cfxaddress.NetowrkTypeMainnetID)
+
+	filter, err := NewAddressFilter(watched.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &receiptClientMock{}
+	converter, err := NewTxDictConverter(&RichClient{client: client})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// failed transaction carrying value to the watched user account
+	failed := hexutil.Uint64(1)
+	blockTime := hexutil.Uint64(1600000000)
+	tx := &types.Transaction{
+		Hash:     types.Hash("0x69b2a4a4e79b5b5d2d1a1eb4d0d1c7e0a6e8d1e0c8b1d7a5e6f2c3b4a5d6e7f8"),
+		From:     other,
+		To:       &watched,
+		Value:    types.NewBigInt(100),
+		Gas:      types.NewBigInt(21000),
+		GasPrice: types.NewBigInt(1),
+		Status:   &failed,
+	}
+	txDict, err := converter.ConvertByTransaction(tx, big.NewFloat(0), &blockTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !txDict.Failed || txDict.Outputs[0].Value.Sign() != 0 {
+		t.Errorf("expect failed transaction without value, actual: %+v", txDict)
+	}
+	if movements := FilterMovements(txDict, filter); len(movements) != 0 {
+		t.Errorf("expect no movement of failed transaction, actual: %+v", movements)
+	}
+
+	// reverted call to contract carrying value by the watched account, the outcome status is known by receipt
+	tx.From, tx.To, tx.Status = watched, &contract, nil
+	client.receipt = &types.TransactionReceipt{To: &contract, OutcomeStatus: 1, GasFee: types.NewBigInt(21000)}
+	txDict, err = converter.ConvertByTransaction(tx, big.NewFloat(0), &blockTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !txDict.Failed || txDict.Inputs[0].Value.Sign() != 0 || txDict.GasFee.Cmp(big.NewInt(21000)) != 0 {
+		t.Errorf("expect failed transaction with only gas fee, actual: %+v", txDict)
+	}
+	if movements := FilterMovements(txDict, filter); len(movements) != 0 {
+		t.Errorf("expect no movement of failed transaction, actual: %+v", movements)
+	}
+}
+
+func TestAddressFilterIsTouchedBy(t *testing.T) {
+	watched := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+	other := cfxaddress.MustNewFromHex("0x160ebef20c1f739957bf9eecd040bce699cc42c6", cfxaddress.NetowrkTypeMainnetID)
+	token := cfxaddress.MustNewFromHex("0x8e2f2e68eb75bb8b18caafe9607242d4748f8d98", cfxaddress.NetowrkTypeMainnetID)
+
+	filter, err := NewAddressFilter(watched.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// tokens are transferred to watched address by calling another contract
+	txDict := richtypes.TxDict{}
+	txDict.Inputs = []richtypes.TxUnit{{Value: big.NewInt(0), Address: &other}, {Value: big.NewInt(2), Address: &other, TokenIdentifier: &token}}
+	txDict.Outputs = []richtypes.TxUnit{{Value: big.NewInt(0), Address: &other}, {Value: big.NewInt(2), Address: &watched, TokenIdentifier: &token}}
+	if !filter.isTouchedBy(&txDict) {
+		t.Fatalf("expect transaction touched by token transfer")
+	}
+
+	filter.SetTokens(nil)
+	if filter.isTouchedBy(&txDict) {
+		t.Fatalf("expect transaction not touched when only CFX is watched")
+	}
+
+	if filter.mayBeTouchedBy(&types.Transaction{From: other, To: &other}) {
+		t.Fatalf("expect transfer between other user accounts skipped")
+	}
+}
@@ -0,0 +1,184 @@
+package walletsdk
+
+import (
+	"math/big"
+
+	sdk "github.com/Conflux-Chain/go-conflux-sdk"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// CreateBatchPayoutTransactions creates unsigned transactions for paying entries from one sender,
+// the nonces are assigned consecutively from the pending nonce of sender or option.Nonce if specified.
+//
+// The gas and storage limit are estimated for every entry with the state nonce of sender, because estimation
+// with a future nonce maybe rejected by node, and they are not affected by nonce for sending token.
+// It checks that sender holds enough CFX for value and fee not covered by sponsor, and tokens for all the transactions,
+// a *richtypes.InsufficientFundsError is returned if not. The balances of sponsor are consumed by the transactions in order,
+// so the fee exceeding the remaining balances of sponsor is counted to sender.
+// The TxDictBase of every transaction is returned together for auditing.
+func (rc *RichClient) CreateBatchPayoutTransactions(from types.Address, entries []richtypes.PayoutEntry, option *richtypes.SendTokenOption) ([]richtypes.PayoutTransaction, error) {
+	batchOption, err := rc.resolveBatchOption(from, option)
+	if err != nil {
+		return nil, err
+	}
+
+	var startNonce *big.Int
+	if option != nil && option.Nonce != nil {
+		startNonce = option.Nonce.ToInt()
+	} else if startNonce, err = rc.getPendingNonce(from); err != nil {
+		return nil, err
+	}
+
+	tc, err := NewTxDictConverter(rc)
+	if err != nil {
+		return nil, errors.Wrap(err, "create TxDictConverter error")
+	}
+
+	tokenRequired := make(map[string]*big.Int)
+	tokenContracts := make(map[string]sdk.Contractor)
+	sponsors := make(map[string]*richtypes.SponsorInfo)
+
+	txs := make([]*types.UnsignedTransaction, len(entries))
+	payouts := make([]richtypes.PayoutTransaction, len(entries))
+	for i, entry := range entries {
+		// estimate with the state nonce of batchOption, then assign the nonce in batch
+		tx, tokenContract, err := rc.createSendTokenTransaction(from, entry.To, entry.Amount, entry.TokenIdentifier, batchOption)
+		if err != nil {
+			return nil, errors.Wrapf(err, "create transaction of entry %v: %+v error", i, entry)
+		}
+		tx.Nonce = types.NewBigIntByRaw(new(big.Int).Add(startNonce, big.NewInt(int64(i))))
+		txs[i] = tx
+
+		// sponsor info is requested once for every contract
+		if tx.To != nil && tx.To.GetAddressType() == cfxaddress.AddressTypeContract {
+			if _, ok := sponsors[tx.To.String()]; !ok {
+				sponsor, err := rc.GetSponsorInfo(*tx.To, &from)
+				if err != nil {
+					return nil, errors.Wrapf(err, "get sponsor info of entry %v: %+v error", i, entry)
+				}
+				sponsors[tx.To.String()] = sponsor
+			}
+		}
+
+		if entry.TokenIdentifier != nil && entry.Amount != nil {
+			tokenKey := entry.TokenIdentifier.String()
+			if tokenRequired[tokenKey] == nil {
+				tokenRequired[tokenKey] = new(big.Int)
+				tokenContracts[tokenKey] = tokenContract
+			}
+			tokenRequired[tokenKey].Add(tokenRequired[tokenKey], entry.Amount.ToInt())
+		}
+
+		payouts[i] = richtypes.PayoutTransaction{
+			Entry:  entry,
+			Tx:     tx,
+			TxDict: tc.ConvertByUnsignedTransaction(tx),
+		}
+	}
+
+	cfxRequired := getBatchSenderMaxCost(txs, sponsors)
+	if err := rc.checkBalanceForSending(from, cfxRequired, tokenRequired, tokenContracts); err != nil {
+		return nil, err
+	}
+
+	return payouts, nil
+}
+
+// getBatchSenderMaxCost returns value and the fee paid by sender of all txs in drip, the keys of sponsors are contract addresses.
+// The balances of sponsor are consumed by txs in order, so the fee is paid by sender when it exceeds the remaining balances.
+func getBatchSenderMaxCost(txs []*types.UnsignedTransaction, sponsors map[string]*richtypes.SponsorInfo) *big.Int {
+	// copy sponsor info to keep balances of sponsors unchanged
+	remainings := make(map[string]*richtypes.SponsorInfo)
+	cost := new(big.Int)
+	for _, tx := range txs {
+		var remaining *richtypes.SponsorInfo
+		if tx.To != nil && sponsors[tx.To.String()] != nil {
+			remaining = remainings[tx.To.String()]
+			if remaining == nil {
+				sponsor := *sponsors[tx.To.String()]
+				sponsor.SponsorBalanceForGas = new(big.Int).Set(sponsor.SponsorBalanceForGas)
+				sponsor.SponsorBalanceForCollateral = new(big.Int).Set(sponsor.SponsorBalanceForCollateral)
+				remaining = &sponsor
+				remainings[tx.To.String()] = remaining
+			}
+		}
+
+		fee := getFeeEstimate(tx, remaining)
+		if fee.GasCoveredBySponsor {
+			remaining.SponsorBalanceForGas.Sub(remaining.SponsorBalanceForGas, fee.GasFee)
+		}
+		if fee.StorageCoveredBySponsor {
+			remaining.SponsorBalanceForCollateral.Sub(remaining.SponsorBalanceForCollateral, fee.StorageCollateral)
+		}
+
+		cost.Add(cost, fee.SenderFee)
+		if tx.Value != nil {
+			cost.Add(cost, tx.Value.ToInt())
+		}
+	}
+	return cost
+}
+
+// resolveBatchOption returns a copy of option whose gas price, epoch height, chain id and state nonce are filled,
+// so that they are only requested once for all transactions of batch.
+func (rc *RichClient) resolveBatchOption(from types.Address, option *richtypes.SendTokenOption) (*richtypes.SendTokenOption, error) {
+	batchOption := richtypes.SendTokenOption{}
+	if option != nil {
+		batchOption = *option
+	}
+
+	if batchOption.GasPrice == nil {
+		if batchOption.GasPriceStrategy == nil {
+			batchOption.GasPriceStrategy = richtypes.ScaledGasPrice(100)
+		}
+		gasPrice, err := batchOption.GasPriceStrategy(rc.client)
+		if err != nil {
+			return nil, errors.Wrap(err, "get gas price by strategy error")
+		}
+		batchOption.GasPrice = gasPrice
+	}
+
+	if batchOption.EpochHeight == nil {
+		epoch, err := rc.client.GetEpochNumber(types.EpochLatestState)
+		if err != nil {
+			return nil, errors.Wrap(err, "get the latest state epoch number error")
+		}
+		batchOption.EpochHeight = types.NewUint64(epoch.ToInt().Uint64())
+	}
+
+	if batchOption.ChainID == nil {
+		status, err := rc.client.GetStatus()
+		if err != nil {
+			return nil, errors.Wrap(err, "get status error")
+		}
+		batchOption.ChainID = &status.ChainID
+	}
+
+	// option.Nonce is only the start nonce of batch, which maybe a future nonce
+	nonce, err := rc.client.GetNextNonce(from)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get next nonce of %v error", from)
+	}
+	batchOption.Nonce = nonce
+
+	return &batchOption, nil
+}
+
+// getPendingNonce returns the next nonce of account counting transactions in pool by txpool_nextNonce,
+// it falls back to cfx_getNextNonce if the node doesn't support it.
+func (rc *RichClient) getPendingNonce(account types.Address) (*big.Int, error) {
+	var nonce *hexutil.Big
+	if err := rc.client.CallRPC(&nonce, "txpool_nextNonce", account); err == nil && nonce != nil {
+		return nonce.ToInt(), nil
+	}
+
+	nonce, err := rc.client.GetNextNonce(account)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get next nonce of %v error", account)
+	}
+	return nonce.ToInt(), nil
+}
@@ -0,0 +1,134 @@
+package walletsdk
+
+import (
+	"math/big"
+	"testing"
+
+	sdk "github.com/Conflux-Chain/go-conflux-sdk"
+	richconstants "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/constants"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// batchClientMock mocks methods used by resolveBatchOption
+type batchClientMock struct {
+	sdk.ClientOperator
+	requests map[string]int
+}
+
+func (c *batchClientMock) GetGasPrice() (*hexutil.Big, error) {
+	c.requests["gasPrice"]++
+	return types.NewBigInt(1000), nil
+}
+
+func (c *batchClientMock) GetEpochNumber(epoch ...*types.Epoch) (*hexutil.Big, error) {
+	c.requests["epochNumber"]++
+	return types.NewBigInt(100), nil
+}
+
+func (c *batchClientMock) GetStatus() (types.Status, error) {
+	c.requests["status"]++
+	return types.Status{ChainID: 1029}, nil
+}
+
+func (c *batchClientMock) GetNextNonce(address types.Address, epoch ...*types.Epoch) (*hexutil.Big, error) {
+	c.requests["nextNonce"]++
+	return types.NewBigInt(7), nil
+}
+
+func TestResolveBatchOption(t *testing.T) {
+	from := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+	client := &batchClientMock{requests: make(map[string]int)}
+	rc := &RichClient{client: client}
+
+	option, err := rc.resolveBatchOption(from, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if option.GasPrice.ToInt().Cmp(big.NewInt(1000)) != 0 || *option.EpochHeight != 100 || *option.ChainID != 1029 ||
+		option.Nonce.ToInt().Cmp(big.NewInt(7)) != 0 {
+		t.Errorf("expect option filled by node, actual: %+v", option)
+	}
+
+	// the specified fields are kept and not requested, except that the nonce for estimation is always the state nonce
+	client.requests = make(map[string]int)
+	epochHeight := hexutil.Uint64(50)
+	chainID := hexutil.Uint(1)
+	specified := &richtypes.SendTokenOption{
+		GasPrice:    types.NewBigInt(2000),
+		Nonce:       types.NewBigInt(3),
+		EpochHeight: &epochHeight,
+		ChainID:     &chainID,
+	}
+	option, err = rc.resolveBatchOption(from, specified)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if option == specified || option.GasPrice.ToInt().Cmp(big.NewInt(2000)) != 0 || *option.EpochHeight != 50 ||
+		*option.ChainID != 1 || option.Nonce.ToInt().Cmp(big.NewInt(7)) != 0 || specified.Nonce.ToInt().Cmp(big.NewInt(3)) != 0 {
+		t.Errorf("expect a copy of option %+v with state nonce, actual: %+v", specified, option)
+	}
+	if len(client.requests) != 1 || client.requests["nextNonce"] != 1 {
+		t.Errorf("expect only state nonce requested for specified fields, actual: %v", client.requests)
+	}
+
+	// gas price is got by strategy
+	option, err = rc.resolveBatchOption(from, &richtypes.SendTokenOption{GasPriceStrategy: richtypes.ScaledGasPrice(150)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if option.GasPrice.ToInt().Cmp(big.NewInt(1500)) != 0 {
+		t.Errorf("expect gas price 1500 by strategy, actual: %v", option.GasPrice)
+	}
+}
+
+func TestGetBatchSenderMaxCost(t *testing.T) {
+	to := cfxaddress.MustNewFromHex("0x160ebef20c1f739957bf9eecd040bce699cc42c6", cfxaddress.NetowrkTypeMainnetID)
+	token := cfxaddress.MustNewFromHex("0x8b8689c7f3014a4d86e4d1d0daaf74a47f5e0f27", cfxaddress.NetowrkTypeMainnetID)
+
+	storageLimit := hexutil.Uint64(64)
+	newTx := func(to *types.Address, value int64) *types.UnsignedTransaction {
+		tx := new(types.UnsignedTransaction)
+		tx.To = to
+		tx.Value = types.NewBigInt(uint64(value))
+		tx.Gas = types.NewBigInt(30000)
+		tx.GasPrice = types.NewBigInt(1000)
+		tx.StorageLimit = &storageLimit
+		return tx
+	}
+	gasFee := big.NewInt(30000 * 1000)
+	collateral := big.NewInt(64 * richconstants.CollateralDripPerStorageByte)
+
+	// the sponsor covers gas fee and storage collateral of only one transaction
+	sponsor := &richtypes.SponsorInfo{
+		SponsorForGas:               &to,
+		SponsorForCollateral:        &to,
+		SponsorGasBound:             big.NewInt(1e8),
+		SponsorBalanceForGas:        new(big.Int).Add(gasFee, big.NewInt(1)),
+		SponsorBalanceForCollateral: new(big.Int).Add(collateral, big.NewInt(1)),
+		IsWhitelisted:               true,
+	}
+	sponsors := map[string]*richtypes.SponsorInfo{token.String(): sponsor}
+
+	txs := []*types.UnsignedTransaction{newTx(&token, 0), newTx(&token, 0), newTx(&to, 100)}
+	expect := new(big.Int).Add(gasFee, collateral)
+	expect.Add(expect, gasFee).Add(expect, collateral).Add(expect, big.NewInt(100))
+	if cost := getBatchSenderMaxCost(txs, sponsors); cost.Cmp(expect) != 0 {
+		t.Errorf("expect cost %v with one token transaction sponsored, actual: %v", expect, cost)
+	}
+
+	// balances of sponsor are not changed
+	if sponsor.SponsorBalanceForGas.Cmp(new(big.Int).Add(gasFee, big.NewInt(1))) != 0 ||
+		sponsor.SponsorBalanceForCollateral.Cmp(new(big.Int).Add(collateral, big.NewInt(1))) != 0 {
+		t.Errorf("expect sponsor unchanged, actual: %+v", sponsor)
+	}
+
+	// nothing is sponsored without sponsor info
+	expect = new(big.Int).Mul(new(big.Int).Add(gasFee, collateral), big.NewInt(3))
+	expect.Add(expect, big.NewInt(100))
+	if cost := getBatchSenderMaxCost(txs, nil); cost.Cmp(expect) != 0 {
+		t.Errorf("expect cost %v without sponsor, actual: %v", expect, cost)
+	}
+}
@@ -0,0 +1,107 @@
+package walletsdk
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/pkg/errors"
+)
+
+// StreamCheckpoint represents the progress of TxDictStream
+type StreamCheckpoint struct {
+	// EpochNumber is the last processed epoch
+	EpochNumber uint64 `json:"epochNumber"`
+	// PivotHashes are pivot hashes of recent processed epochs, they are used to detect pivot chain reorg
+	PivotHashes map[uint64]types.Hash `json:"pivotHashes"`
+}
+
+// CheckpointStore persists checkpoint of TxDictStream
+type CheckpointStore interface {
+	// LoadCheckpoint returns the saved checkpoint, it returns nil if there is no checkpoint
+	LoadCheckpoint() (*StreamCheckpoint, error)
+	// SaveCheckpoint saves the checkpoint
+	SaveCheckpoint(checkpoint *StreamCheckpoint) error
+}
+
+// MemoryCheckpointStore keeps checkpoint in memory
+type MemoryCheckpointStore struct {
+	mutex      sync.Mutex
+	checkpoint *StreamCheckpoint
+}
+
+// LoadCheckpoint implements CheckpointStore
+func (ms *MemoryCheckpointStore) LoadCheckpoint() (*StreamCheckpoint, error) {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+	return copyCheckpoint(ms.checkpoint), nil
+}
+
+// SaveCheckpoint implements CheckpointStore
+func (ms *MemoryCheckpointStore) SaveCheckpoint(checkpoint *StreamCheckpoint) error {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+	ms.checkpoint = copyCheckpoint(checkpoint)
+	return nil
+}
+
+// FileCheckpointStore keeps checkpoint in a json file
+type FileCheckpointStore struct {
+	Path string
+}
+
+// NewFileCheckpointStore creates a FileCheckpointStore with file path
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{Path: path}
+}
+
+// LoadCheckpoint implements CheckpointStore
+func (fs *FileCheckpointStore) LoadCheckpoint() (*StreamCheckpoint, error) {
+	content, err := ioutil.ReadFile(fs.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "read checkpoint file %v error", fs.Path)
+	}
+
+	var checkpoint StreamCheckpoint
+	if err := json.Unmarshal(content, &checkpoint); err != nil {
+		return nil, errors.Wrapf(err, "unmarshal checkpoint file %v error", fs.Path)
+	}
+	return &checkpoint, nil
+}
+
+// SaveCheckpoint implements CheckpointStore, it writes a temporary file and renames it to avoid broken checkpoint file
+func (fs *FileCheckpointStore) SaveCheckpoint(checkpoint *StreamCheckpoint) error {
+	content, err := json.Marshal(checkpoint)
+	if err != nil {
+		return errors.Wrap(err, "marshal checkpoint error")
+	}
+
+	tmpPath := fs.Path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, content, 0644); err != nil {
+		return errors.Wrapf(err, "write checkpoint file %v error", tmpPath)
+	}
+	if err := os.Rename(tmpPath, fs.Path); err != nil {
+		return errors.Wrapf(err, "rename checkpoint file %v to %v error", tmpPath, fs.Path)
+	}
+	return nil
+}
+
+func copyCheckpoint(checkpoint *StreamCheckpoint) *StreamCheckpoint {
+	if checkpoint == nil {
+		return nil
+	}
+
+	copied := StreamCheckpoint{
+		EpochNumber: checkpoint.EpochNumber,
+		PivotHashes: make(map[uint64]types.Hash, len(checkpoint.PivotHashes)),
+	}
+	for epoch, hash := range checkpoint.PivotHashes {
+		copied.PivotHashes[epoch] = hash
+	}
+	return &copied
+}
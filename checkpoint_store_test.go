@@ -0,0 +1,41 @@
+package walletsdk
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+)
+
+func TestFileCheckpointStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "checkpoint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store := NewFileCheckpointStore(filepath.Join(dir, "checkpoint.json"))
+
+	checkpoint, err := store.LoadCheckpoint()
+	if err != nil || checkpoint != nil {
+		t.Fatalf("expect no checkpoint, actual: %+v, %v", checkpoint, err)
+	}
+
+	saved := &StreamCheckpoint{
+		EpochNumber: 100,
+		PivotHashes: map[uint64]types.Hash{100: "0x28d5a5b1b8f6c83e274b7ba1f027d16215596f27ea5effb745994401d23f8a18"},
+	}
+	if err := store.SaveCheckpoint(saved); err != nil {
+		t.Fatal(err)
+	}
+
+	checkpoint, err = store.LoadCheckpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkpoint.EpochNumber != saved.EpochNumber || checkpoint.PivotHashes[100] != saved.PivotHashes[100] {
+		t.Fatalf("expect %+v, actual: %+v", saved, checkpoint)
+	}
+}
@@ -0,0 +1,413 @@
+package walletsdk
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	sdk "github.com/Conflux-Chain/go-conflux-sdk"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/pkg/errors"
+)
+
+// default values of ConfirmationTrackerOption
+var (
+	defaultTrackerPollInterval     = time.Second
+	defaultConfirmedRiskThreshold  = big.NewFloat(1e-8)
+	defaultTrackerDropEpochs       = uint64(100)
+	defaultTrackerEventChannelSize = 64
+)
+
+// ConfirmationTrackerOption is the option of ConfirmationTracker, the zero value fields will use default values
+type ConfirmationTrackerOption struct {
+	// PollInterval is the interval to check whether new epoch arrives, default is 1 second
+	PollInterval time.Duration
+	// ConfirmedRiskThreshold is the max revert rate of block for regarding transaction as confirmed, default is 1e-8
+	ConfirmedRiskThreshold *big.Float
+	// RiskThresholds are extra thresholds, an event is emitted once revert rate of block falls to each of them
+	RiskThresholds []*big.Float
+	// DropEpochs is the number of epochs a transaction not found on both chain and pool before regarded as dropped, default is 100
+	DropEpochs uint64
+	// OnEvent is called when event emitted, the events are sent to channel returned by Events() if it is nil
+	OnEvent func(event richtypes.TxConfirmationEvent)
+	// OnError is called when error occurs in polling started by Start()
+	OnError func(err error)
+	// EventChannelSize is the buffer size of event channel, default is 64
+	EventChannelSize int
+}
+
+// ConfirmationTracker watches transactions and emits events when their confirmation status changed,
+// finalized and dropped transactions are untracked automatically.
+type ConfirmationTracker struct {
+	client sdk.ClientOperator
+	option ConfirmationTrackerOption
+	events chan richtypes.TxConfirmationEvent
+
+	mutex     sync.Mutex
+	txs       map[types.Hash]*trackedTx
+	lastEpoch *big.Int
+	stop      chan struct{}
+}
+
+type trackedTx struct {
+	status    richtypes.TxConfirmationStatus
+	blockHash *types.Hash
+	from      *types.Address
+	nonce     *big.Int
+	// missingSince is the latest state epoch when transaction is found missing at first
+	missingSince *big.Int
+	// crossed[i] is true if RiskThresholds[i] is crossed for current block
+	crossed []bool
+}
+
+func (t *trackedTx) clone() *trackedTx {
+	cloned := *t
+	cloned.crossed = append([]bool(nil), t.crossed...)
+	return &cloned
+}
+
+type trackerEpochs struct {
+	latestState      *big.Int
+	latestConfirmed  *big.Int
+	latestCheckpoint *big.Int
+}
+
+// NewConfirmationTracker creates a ConfirmationTracker, option could be nil to use default values
+func NewConfirmationTracker(client sdk.ClientOperator, option *ConfirmationTrackerOption) *ConfirmationTracker {
+	ct := &ConfirmationTracker{
+		client: client,
+		txs:    make(map[types.Hash]*trackedTx),
+	}
+
+	if option != nil {
+		ct.option = *option
+	}
+	if ct.option.PollInterval <= 0 {
+		ct.option.PollInterval = defaultTrackerPollInterval
+	}
+	if ct.option.ConfirmedRiskThreshold == nil {
+		ct.option.ConfirmedRiskThreshold = defaultConfirmedRiskThreshold
+	}
+	if ct.option.DropEpochs == 0 {
+		ct.option.DropEpochs = defaultTrackerDropEpochs
+	}
+	if ct.option.EventChannelSize <= 0 {
+		ct.option.EventChannelSize = defaultTrackerEventChannelSize
+	}
+
+	ct.events = make(chan richtypes.TxConfirmationEvent, ct.option.EventChannelSize)
+	return ct
+}
+
+// Events returns the channel of events, it is only used when OnEvent of option is nil
+// and should be consumed in time, otherwise the tracker will be blocked.
+func (ct *ConfirmationTracker) Events() <-chan richtypes.TxConfirmationEvent {
+	return ct.events
+}
+
+// Track adds transactions to be tracked
+func (ct *ConfirmationTracker) Track(hashes ...types.Hash) {
+	ct.mutex.Lock()
+	defer ct.mutex.Unlock()
+
+	for _, hash := range hashes {
+		if _, ok := ct.txs[hash]; !ok {
+			ct.txs[hash] = &trackedTx{crossed: make([]bool, len(ct.option.RiskThresholds))}
+		}
+	}
+}
+
+// Untrack removes transactions from tracking
+func (ct *ConfirmationTracker) Untrack(hashes ...types.Hash) {
+	ct.mutex.Lock()
+	defer ct.mutex.Unlock()
+
+	for _, hash := range hashes {
+		delete(ct.txs, hash)
+	}
+}
+
+// Tracking returns hashes of transactions under tracking
+func (ct *ConfirmationTracker) Tracking() []types.Hash {
+	ct.mutex.Lock()
+	defer ct.mutex.Unlock()
+
+	hashes := make([]types.Hash, 0, len(ct.txs))
+	for hash := range ct.txs {
+		hashes = append(hashes, hash)
+	}
+	return hashes
+}
+
+// Start starts polling in a new goroutine, all tracked transactions are re-evaluated when new epoch arrives
+func (ct *ConfirmationTracker) Start() {
+	ct.mutex.Lock()
+	defer ct.mutex.Unlock()
+
+	if ct.stop != nil {
+		return
+	}
+	ct.stop = make(chan struct{})
+
+	go func(stop chan struct{}) {
+		ticker := time.NewTicker(ct.option.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if err := ct.poll(); err != nil && ct.option.OnError != nil {
+					ct.option.OnError(err)
+				}
+			}
+		}
+	}(ct.stop)
+}
+
+// Stop stops polling started by Start
+func (ct *ConfirmationTracker) Stop() {
+	ct.mutex.Lock()
+	defer ct.mutex.Unlock()
+
+	if ct.stop != nil {
+		close(ct.stop)
+		ct.stop = nil
+	}
+}
+
+// poll re-evaluates tracked transactions only if new epoch arrives
+func (ct *ConfirmationTracker) poll() error {
+	latestState, err := ct.client.GetEpochNumber(types.EpochLatestState)
+	if err != nil {
+		return errors.Wrap(err, "get latest state epoch error")
+	}
+
+	ct.mutex.Lock()
+	isNewEpoch := ct.lastEpoch == nil || ct.lastEpoch.Cmp(latestState.ToInt()) != 0
+	ct.mutex.Unlock()
+
+	if !isNewEpoch {
+		return nil
+	}
+	return ct.Update()
+}
+
+// Update re-evaluates all tracked transactions immediately and emits events
+func (ct *ConfirmationTracker) Update() error {
+	epochs, err := ct.getEpochs()
+	if err != nil {
+		return err
+	}
+
+	ct.mutex.Lock()
+	txs := make(map[types.Hash]*trackedTx, len(ct.txs))
+	for hash, tx := range ct.txs {
+		txs[hash] = tx
+	}
+	ct.mutex.Unlock()
+
+	var errs []error
+	for hash, tracked := range txs {
+		// evaluate a copy so that tracked transactions are never mutated out of lock
+		updated := tracked.clone()
+		events, err := ct.evaluate(hash, updated, epochs)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if !ct.swap(hash, tracked, updated) {
+			continue
+		}
+
+		for _, event := range events {
+			ct.emit(event)
+		}
+	}
+
+	if len(errs) > 0 {
+		return joinError(errs)
+	}
+
+	// the epoch is polled again if any transaction failed to evaluate
+	ct.mutex.Lock()
+	ct.lastEpoch = epochs.latestState
+	ct.mutex.Unlock()
+	return nil
+}
+
+// swap replaces tracked transaction of hash by updated, the finalized or dropped transaction is untracked.
+// It returns false if the transaction is untracked or replaced by others during evaluation.
+func (ct *ConfirmationTracker) swap(hash types.Hash, tracked, updated *trackedTx) bool {
+	ct.mutex.Lock()
+	defer ct.mutex.Unlock()
+
+	if ct.txs[hash] != tracked {
+		return false
+	}
+
+	if updated.status == richtypes.TxStatusFinalized || updated.status == richtypes.TxStatusDropped {
+		delete(ct.txs, hash)
+	} else {
+		ct.txs[hash] = updated
+	}
+	return true
+}
+
+func (ct *ConfirmationTracker) getEpochs() (*trackerEpochs, error) {
+	latestState, err := ct.client.GetEpochNumber(types.EpochLatestState)
+	if err != nil {
+		return nil, errors.Wrap(err, "get latest state epoch error")
+	}
+
+	latestConfirmed, err := ct.client.GetEpochNumber(types.EpochLatestConfirmed)
+	if err != nil {
+		return nil, errors.Wrap(err, "get latest confirmed epoch error")
+	}
+
+	latestCheckpoint, err := ct.client.GetEpochNumber(types.EpochLatestCheckpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "get latest checkpoint epoch error")
+	}
+
+	return &trackerEpochs{
+		latestState:      latestState.ToInt(),
+		latestConfirmed:  latestConfirmed.ToInt(),
+		latestCheckpoint: latestCheckpoint.ToInt(),
+	}, nil
+}
+
+// evaluate updates status of tracked transaction and returns events should be emitted,
+// the tracked should not be shared with other goroutines.
+func (ct *ConfirmationTracker) evaluate(hash types.Hash, tracked *trackedTx, epochs *trackerEpochs) ([]richtypes.TxConfirmationEvent, error) {
+	tx, err := ct.client.GetTransactionByHash(hash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get transaction by hash %v error", hash)
+	}
+
+	event := richtypes.TxConfirmationEvent{TxHash: hash}
+
+	if tx == nil {
+		dropped, err := ct.isNonceUsed(tracked.from, tracked.nonce)
+		if err != nil {
+			return nil, err
+		}
+
+		if tracked.missingSince == nil {
+			tracked.missingSince = epochs.latestState
+		}
+		missingEpochs := new(big.Int).Sub(epochs.latestState, tracked.missingSince)
+		if dropped || missingEpochs.Cmp(new(big.Int).SetUint64(ct.option.DropEpochs)) >= 0 {
+			return ct.changeStatus(tracked, richtypes.TxStatusDropped, event), nil
+		}
+		return nil, nil
+	}
+
+	tracked.missingSince = nil
+	tracked.from = &tx.From
+	tracked.nonce = tx.Nonce.ToInt()
+
+	if tx.BlockHash == nil {
+		dropped, err := ct.isNonceUsed(tracked.from, tracked.nonce)
+		if err != nil {
+			return nil, err
+		}
+		if dropped {
+			return ct.changeStatus(tracked, richtypes.TxStatusDropped, event), nil
+		}
+		tracked.blockHash = nil
+		return ct.changeStatus(tracked, richtypes.TxStatusPending, event), nil
+	}
+
+	// the transaction is packed in another block after chain reorganized, so thresholds should be crossed again
+	if tracked.blockHash == nil || *tracked.blockHash != *tx.BlockHash {
+		tracked.crossed = make([]bool, len(ct.option.RiskThresholds))
+	}
+	tracked.blockHash = tx.BlockHash
+	event.BlockHash = tx.BlockHash
+
+	receipt, err := ct.client.GetTransactionReceipt(hash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get transaction receipt by hash %v error", hash)
+	}
+	if receipt == nil {
+		return ct.changeStatus(tracked, richtypes.TxStatusMined, event), nil
+	}
+
+	outcomeStatus := receipt.OutcomeStatus
+	event.OutcomeStatus = &outcomeStatus
+	event.EpochNumber = receipt.EpochNumber
+
+	revertRate, err := ct.client.GetBlockConfirmationRisk(*tx.BlockHash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get block revert rate by hash %v error", tx.BlockHash)
+	}
+	event.RevertRate = revertRate
+
+	status := richtypes.TxStatusExecuted
+	if receipt.EpochNumber != nil {
+		epoch := new(big.Int).SetUint64(uint64(*receipt.EpochNumber))
+		if epoch.Cmp(epochs.latestCheckpoint) <= 0 {
+			status = richtypes.TxStatusFinalized
+		} else if epoch.Cmp(epochs.latestConfirmed) <= 0 {
+			status = richtypes.TxStatusConfirmed
+		}
+	}
+	if status == richtypes.TxStatusExecuted && revertRate != nil && revertRate.Cmp(ct.option.ConfirmedRiskThreshold) <= 0 {
+		status = richtypes.TxStatusConfirmed
+	}
+
+	events := ct.changeStatus(tracked, status, event)
+
+	if revertRate != nil {
+		for i, threshold := range ct.option.RiskThresholds {
+			if tracked.crossed[i] || revertRate.Cmp(threshold) > 0 {
+				continue
+			}
+			tracked.crossed[i] = true
+
+			thresholdEvent := event
+			thresholdEvent.Status = tracked.status
+			thresholdEvent.RiskThreshold = threshold
+			events = append(events, thresholdEvent)
+		}
+	}
+
+	return events, nil
+}
+
+// changeStatus updates status of tracked transaction and returns the event if status changed
+func (ct *ConfirmationTracker) changeStatus(tracked *trackedTx, status richtypes.TxConfirmationStatus, event richtypes.TxConfirmationEvent) []richtypes.TxConfirmationEvent {
+	if tracked.status == status {
+		return nil
+	}
+
+	event.PrevStatus = tracked.status
+	event.Status = status
+	tracked.status = status
+	return []richtypes.TxConfirmationEvent{event}
+}
+
+// isNonceUsed returns true if nonce of sender is larger than nonce, it returns false if sender is unknown
+func (ct *ConfirmationTracker) isNonceUsed(from *types.Address, nonce *big.Int) (bool, error) {
+	if from == nil || nonce == nil {
+		return false, nil
+	}
+
+	nextNonce, err := ct.client.GetNextNonce(*from)
+	if err != nil {
+		return false, errors.Wrapf(err, "get next nonce of %v error", from)
+	}
+	return nextNonce.ToInt().Cmp(nonce) > 0, nil
+}
+
+func (ct *ConfirmationTracker) emit(event richtypes.TxConfirmationEvent) {
+	if ct.option.OnEvent != nil {
+		ct.option.OnEvent(event)
+		return
+	}
+	ct.events <- event
+}
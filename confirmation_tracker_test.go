@@ -0,0 +1,196 @@
+package walletsdk
+
+import (
+	"errors"
+	"math/big"
+	"runtime"
+	"sync"
+	"testing"
+
+	sdk "github.com/Conflux-Chain/go-conflux-sdk"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// trackerClientMock mocks methods used by ConfirmationTracker
+type trackerClientMock struct {
+	sdk.ClientOperator
+	epochs     map[string]uint64
+	tx         *types.Transaction
+	receipt    *types.TransactionReceipt
+	revertRate *big.Float
+	txErr      error
+}
+
+func (c *trackerClientMock) GetEpochNumber(epoch ...*types.Epoch) (*hexutil.Big, error) {
+	return types.NewBigInt(c.epochs[epoch[0].String()]), nil
+}
+
+func (c *trackerClientMock) GetTransactionByHash(hash types.Hash) (*types.Transaction, error) {
+	// yield like a real RPC so that concurrent evaluations interleave
+	runtime.Gosched()
+	return c.tx, c.txErr
+}
+
+func (c *trackerClientMock) GetTransactionReceipt(hash types.Hash) (*types.TransactionReceipt, error) {
+	return c.receipt, nil
+}
+
+func (c *trackerClientMock) GetBlockConfirmationRisk(hash types.Hash) (*big.Float, error) {
+	return c.revertRate, nil
+}
+
+func (c *trackerClientMock) GetNextNonce(address types.Address, epoch ...*types.Epoch) (*hexutil.Big, error) {
+	return types.NewBigInt(0), nil
+}
+
+func TestConfirmationTracker(t *testing.T) {
+	hash := types.Hash("0x69b2a4a4e79b5b5d2d1a1eb4d0d1c7e0a6e8d1e0c8b1d7a5e6f2c3b4a5d6e7f8")
+	blockHash := types.Hash("0x28d5a5b1b8f6c83e274b7ba1f027d16215596f27ea5effb745994401d23f8a18")
+	epochNumber := hexutil.Uint64(100)
+
+	client := &trackerClientMock{
+		epochs: map[string]uint64{"latest_state": 110, "latest_confirmed": 50, "latest_checkpoint": 10},
+		tx: &types.Transaction{
+			Hash:  hash,
+			From:  cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID),
+			Nonce: types.NewBigInt(0),
+		},
+	}
+
+	var events []richtypes.TxConfirmationEvent
+	tracker := NewConfirmationTracker(client, &ConfirmationTrackerOption{
+		RiskThresholds: []*big.Float{big.NewFloat(1e-4)},
+		OnEvent:        func(event richtypes.TxConfirmationEvent) { events = append(events, event) },
+	})
+	tracker.Track(hash)
+
+	expectStatus := func(status richtypes.TxConfirmationStatus) {
+		if err := tracker.Update(); err != nil {
+			t.Fatal(err)
+		}
+		if len(events) == 0 || events[len(events)-1].Status != status {
+			t.Fatalf("expect status %v, actual events: %+v", status, events)
+		}
+	}
+
+	expectStatus(richtypes.TxStatusPending)
+
+	client.tx.BlockHash = &blockHash
+	expectStatus(richtypes.TxStatusMined)
+
+	client.receipt = &types.TransactionReceipt{EpochNumber: &epochNumber}
+	client.revertRate = big.NewFloat(1e-3)
+	expectStatus(richtypes.TxStatusExecuted)
+
+	client.revertRate = big.NewFloat(1e-5)
+	expectStatus(richtypes.TxStatusExecuted)
+	if events[len(events)-1].RiskThreshold == nil {
+		t.Fatalf("expect risk threshold crossed event, actual: %+v", events[len(events)-1])
+	}
+
+	client.revertRate = big.NewFloat(1e-9)
+	expectStatus(richtypes.TxStatusConfirmed)
+
+	client.epochs["latest_checkpoint"] = 100
+	expectStatus(richtypes.TxStatusFinalized)
+
+	if len(tracker.Tracking()) != 0 {
+		t.Fatalf("expect finalized transaction untracked")
+	}
+}
+
+func TestConfirmationTrackerConcurrentTrack(t *testing.T) {
+	client := &trackerClientMock{
+		epochs: map[string]uint64{"latest_state": 110, "latest_confirmed": 50, "latest_checkpoint": 10},
+		tx: &types.Transaction{
+			From:  cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID),
+			Nonce: types.NewBigInt(0),
+		},
+	}
+
+	tracker := NewConfirmationTracker(client, &ConfirmationTrackerOption{
+		RiskThresholds: []*big.Float{big.NewFloat(1e-4)},
+		OnEvent:        func(event richtypes.TxConfirmationEvent) {},
+	})
+
+	hashes := make([]types.Hash, 100)
+	for i := range hashes {
+		hashes[i] = types.Hash(hexutil.Encode(common.BigToHash(big.NewInt(int64(i))).Bytes()))
+	}
+
+	update := func(wg *sync.WaitGroup) {
+		defer wg.Done()
+		for range hashes {
+			if err := tracker.Update(); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}
+
+	// Update is called by polling and users concurrently
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		for _, hash := range hashes {
+			tracker.Track(hash)
+			tracker.Tracking()
+		}
+	}()
+	go update(&wg)
+	go update(&wg)
+	wg.Wait()
+
+	if err := tracker.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if len(tracker.Tracking()) != len(hashes) {
+		t.Fatalf("expect %v transactions tracked, actual %v", len(hashes), len(tracker.Tracking()))
+	}
+}
+
+func TestConfirmationTrackerRetryFailedEvaluation(t *testing.T) {
+	hash := types.Hash("0x69b2a4a4e79b5b5d2d1a1eb4d0d1c7e0a6e8d1e0c8b1d7a5e6f2c3b4a5d6e7f8")
+	client := &trackerClientMock{
+		epochs: map[string]uint64{"latest_state": 110, "latest_confirmed": 50, "latest_checkpoint": 10},
+		tx: &types.Transaction{
+			Hash:  hash,
+			From:  cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID),
+			Nonce: types.NewBigInt(0),
+		},
+		txErr: errors.New("rpc error"),
+	}
+
+	var events []richtypes.TxConfirmationEvent
+	tracker := NewConfirmationTracker(client, &ConfirmationTrackerOption{
+		OnEvent: func(event richtypes.TxConfirmationEvent) { events = append(events, event) },
+	})
+	tracker.Track(hash)
+
+	if err := tracker.poll(); err == nil {
+		t.Fatal("expect evaluation error")
+	}
+
+	// the failed evaluation is retried in the same epoch
+	client.txErr = nil
+	if err := tracker.poll(); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Status != richtypes.TxStatusPending {
+		t.Fatalf("expect pending event by retry, actual: %+v", events)
+	}
+
+	// the transaction is not evaluated again until new epoch arrives
+	if err := tracker.poll(); err != nil {
+		t.Fatal(err)
+	}
+	client.txErr = errors.New("rpc error")
+	if err := tracker.poll(); err != nil || len(events) != 1 {
+		t.Fatalf("expect no evaluation in the same epoch, error: %v, events: %+v", err, events)
+	}
+}
@@ -3,19 +3,53 @@ package constants
 import (
 	"github.com/Conflux-Chain/go-conflux-sdk/types"
 	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 const (
 	// RPCConcurrence represents rpc request concurrence with conflux full node
 	RPCConcurrence = 10
+
+	// CollateralDripPerStorageByte represents drip collateralized for each byte of storage, that is 1 CFX for 1024 bytes
+	CollateralDripPerStorageByte = 976562500000000
+
+	// MinGasPriceBumpPercent represents the min percent of gas price raised when replacing a pending transaction
+	MinGasPriceBumpPercent = 10
 )
 
 var (
 	// erc20, err = sdk.NewContract(nil, nil, nil)
 	Erc20TransferFuncSign = "0xa9059cbb"
 	Erc777SendFuncSign    = "0x9bd9bbc6"
+	Erc721OwnerOfFuncSign = "0x6352211e"
+
+	// Erc721InterfaceID represents the ERC165 interface id of erc721
+	Erc721InterfaceID = [4]byte{0x80, 0xac, 0x58, 0xcd}
 
 	// TethysFcV1Address represents Tethys Fc Contract Address
 	TethysFcV1Address   = cfxaddress.MustNewFromHex("0x8e2f2e68eb75bb8b18caafe9607242d4748f8d98", 1029)
 	Erc777SentEventSign = types.Hash("0x06b541ddaa720db2b10a4d0cdac39b8d360425fc073085fac19bc82614677987")
+
+	// CrossSpaceCallHexAddress represents hex address of CrossSpaceCall internal contract, which is the same on all networks
+	CrossSpaceCallHexAddress = common.HexToAddress("0x0888000000000000000000000000000000000006")
+
+	// StakingHexAddress represents hex address of Staking internal contract, which is the same on all networks
+	StakingHexAddress = common.HexToAddress("0x0888000000000000000000000000000000000002")
+
+	// SponsorWhitelistControlHexAddress represents hex address of SponsorWhitelistControl internal contract, which is the same on all networks
+	SponsorWhitelistControlHexAddress = common.HexToAddress("0x0888000000000000000000000000000000000001")
+
+	// AdminControlHexAddress represents hex address of AdminControl internal contract, which is the same on all networks
+	AdminControlHexAddress = common.HexToAddress("0x0888000000000000000000000000000000000000")
+
+	// WrappedCFXHexAddresses maps network id to hex addresses of well-known wrapped CFX contracts
+	WrappedCFXHexAddresses = map[uint32][]common.Address{
+		cfxaddress.NetowrkTypeMainnetID: {common.HexToAddress("0x8d7df9316faa0586e175b5e6d03c6bda76e3d950")},
+		cfxaddress.NetworkTypeTestnetID: {common.HexToAddress("0x88c27bd05a7a58bafed6797efa0cce4e1d55302f")},
+	}
+
+	// DexRouterHexAddresses maps network id to hex addresses of well-known Uniswap V2 style DEX routers
+	DexRouterHexAddresses = map[uint32][]common.Address{
+		cfxaddress.NetowrkTypeMainnetID: {common.HexToAddress("0x80ae6a88ce3351e9f729e8199f2871ba786ad7c5")},
+	}
 )
@@ -12,8 +12,10 @@ import (
 	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/decoder"
 	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/helper"
 	walletinterface "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/interface"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/resource/contract/abi"
 	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
 	"github.com/Conflux-Chain/go-conflux-sdk/constants"
+	"github.com/Conflux-Chain/go-conflux-sdk/rpc"
 	"github.com/Conflux-Chain/go-conflux-sdk/types"
 	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
 	"github.com/ethereum/go-ethereum/common"
@@ -28,6 +30,16 @@ type TxDictConverter struct {
 	decoder    *decoder.ContractDecoder
 	mutex      *sync.Mutex
 	networkID  uint32
+	// wrappedCFXs contains hex addresses of wrapped CFX contracts
+	wrappedCFXs map[common.Address]struct{}
+	// pairTokens caches token0 and token1 of DEX pairs, the tokens are nil for contracts which are not pairs
+	pairTokens map[string][2]*types.Address
+	// dexRouters contains hex addresses of DEX routers
+	dexRouters map[common.Address]struct{}
+	// dexFactories caches hex addresses of factories of DEX routers
+	dexFactories map[common.Address]common.Address
+	// contractTypes caches contract types settled for function signatures shared by contract types
+	contractTypes map[string]richtypes.ContractType
 }
 
 // NewTxDictConverter creates a TxDictConverter instance.
@@ -43,6 +55,10 @@ func NewTxDictConverter(richClient walletinterface.RichClientOperator) (*TxDictC
 		decoder:    contractDecoder,
 		mutex:      new(sync.Mutex),
 		networkID:  cfxaddress.NetowrkTypeMainnetID,
+		pairTokens: make(map[string][2]*types.Address),
+
+		dexFactories:  make(map[common.Address]common.Address),
+		contractTypes: make(map[string]richtypes.ContractType),
 	}
 
 	if richClient != nil {
@@ -52,6 +68,8 @@ func NewTxDictConverter(richClient walletinterface.RichClientOperator) (*TxDictC
 		}
 		tc.networkID = _networkID
 	}
+	tc.setDefaultWrappedCFXTokens()
+	tc.setDefaultDexRouters()
 
 	return &tc, nil
 }
@@ -64,12 +82,36 @@ func (tc *TxDictConverter) ConvertByTokenTransferEvent(tte *richtypes.TokenTrans
 	txDict.RevertRate = tte.RevertRate
 	txDict.TxAt = tte.Timestamp
 
-	value, ok := new(big.Int).SetString(tte.Value, 0)
+	// the value of erc721 transfer is one token specified by token id
+	tteValue := tte.Value
+	if tteValue == "" && tte.TransferType == richtypes.TransferTypeERC721 {
+		tteValue = "1"
+	}
+	value, ok := new(big.Int).SetString(tteValue, 0)
 	if !ok {
 		msg := fmt.Sprintf("Convert TokenTransferEvent.Value %v to *big.Int fail", tte.Value)
 		return nil, errors.New(msg)
 	}
 
+	// nothing is transferred except the gas fee by failed transaction
+	if tte.Status != 0 {
+		txDict.Failed = true
+		value = big.NewInt(0)
+	}
+
+	if tte.GasFee != "" {
+		if txDict.GasFee, ok = new(big.Int).SetString(tte.GasFee, 0); !ok {
+			return nil, fmt.Errorf("Convert TokenTransferEvent.GasFee %v to *big.Int fail", tte.GasFee)
+		}
+	}
+
+	var tokenID *big.Int
+	if tte.TokenID != "" {
+		if tokenID, ok = new(big.Int).SetString(tte.TokenID, 0); !ok {
+			return nil, fmt.Errorf("Convert TokenTransferEvent.TokenID %v to *big.Int fail", tte.TokenID)
+		}
+	}
+
 	txDict.Inputs = []richtypes.TxUnit{
 		{
 			Value:           value,
@@ -78,6 +120,7 @@ func (tc *TxDictConverter) ConvertByTokenTransferEvent(tte *richtypes.TokenTrans
 			TokenCode:       tte.TokenSymbol,
 			TokenDecimal:    tte.TokenDecimal,
 			TokenIdentifier: tte.ContractAddress,
+			TokenId:         tokenID,
 		},
 	}
 
@@ -89,8 +132,10 @@ func (tc *TxDictConverter) ConvertByTokenTransferEvent(tte *richtypes.TokenTrans
 			TokenCode:       tte.TokenSymbol,
 			TokenDecimal:    tte.TokenDecimal,
 			TokenIdentifier: tte.ContractAddress,
+			TokenId:         tokenID,
 		},
 	}
+	setMintOrBurnKind(&txDict.Inputs[0], &txDict.Outputs[0])
 	return txDict, nil
 }
 
@@ -114,6 +159,11 @@ func (tc *TxDictConverter) ConvertByTransaction(tx *types.Transaction, revertRat
 	tc.fillTxDictByTx(txDict, tx.From.MustGetCommonAddress(), helper.MustGetCommonAddressPtr(tx.To), tx.Value, &sn)
 	// fmt.Println("create txdict done")
 
+	// the status is nil if transaction is not executed yet
+	if tx.Status != nil && *tx.Status != 0 {
+		setTxDictFailed(txDict)
+	}
+
 	// no log will produced when transaction to is normal account or nil, so return
 	if tx.To == nil {
 		return txDict, nil
@@ -144,14 +194,46 @@ func (tc *TxDictConverter) ConvertByTransaction(tx *types.Transaction, revertRat
 	}
 	// fmt.Println("get tx receipt done")
 
+	if receipit.GasFee != nil {
+		txDict.GasFee = receipit.GasFee.ToInt()
+	}
+	txDict.GasCoveredBySponsor = receipit.GasCoveredBySponsor
+	txDict.StorageCoveredBySponsor = receipit.StorageCoveredBySponsor
+
+	// no log is emitted by failed transaction, such as reverted or not enough cash
+	if receipit.OutcomeStatus != 0 {
+		setTxDictFailed(txDict)
+		return txDict, nil
+	}
+
 	err = tc.fillTxDictByTxReceipt(txDict, receipit, &sn)
 	// fmt.Printf("after fill by receipt: %+v\n\n", txDict)
 	if err != nil {
 		return nil, errors.Wrapf(err, "fill tx_dict by tx receipt %v error", receipit)
 	}
+
+	// staking operations produce no log, so decode them by data of transaction which is executed successfully
+	if decoder.IsStaking(tx.To) && receipit.OutcomeStatus == 0 {
+		data, err := hexutil.Decode(tx.Data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "decode data of transaction %v error", tx.Hash)
+		}
+		tc.fillTxDictByStakingFunction(&txDict.TxDictBase, &tx.From, data, &sn)
+	}
 	return txDict, nil
 }
 
+// setTxDictFailed marks txDict failed, and nothing is transferred except the gas fee by failed transaction
+func setTxDictFailed(txDict *richtypes.TxDict) {
+	txDict.Failed = true
+	for i := range txDict.Inputs {
+		txDict.Inputs[i].Value = big.NewInt(0)
+	}
+	for i := range txDict.Outputs {
+		txDict.Outputs[i].Value = big.NewInt(0)
+	}
+}
+
 func (tc *TxDictConverter) createTxDict(tx *types.Transaction, revertRate *big.Float, blockTime *hexutil.Uint64) (*richtypes.TxDict, error) {
 
 	// fmt.Println("start creat txdict")
@@ -239,6 +321,8 @@ func (tc *TxDictConverter) fillTxDictByTxReceipt(txDict *richtypes.TxDict, recei
 		return nil
 	}
 
+	counter := make(mintBurnCounter)
+	dexEvents := make([]dexEvent, 0)
 	// sn := uint64(0)
 	for _, log := range logs {
 
@@ -247,6 +331,47 @@ func (tc *TxDictConverter) fillTxDictByTxReceipt(txDict *richtypes.TxDict, recei
 			continue
 		}
 
+		// cfx moved between core space and espace by CrossSpaceCall
+		if decoder.IsCrossSpaceCall(&log.Address) {
+			if err := tc.fillTxDictByCrossSpaceEvent(&txDict.TxDictBase, &log, sn); err != nil {
+				return errors.Wrapf(err, "fill cross space transfer by log %+v error", log)
+			}
+			continue
+		}
+
+		// cfx wrapped or unwrapped by wrapped CFX contracts
+		if tc.isWrappedCFX(&log.Address) {
+			filled, err := tc.fillTxDictByWrappedCFXEvent(&txDict.TxDictBase, &log, sn)
+			if err != nil {
+				return errors.Wrapf(err, "fill wrapped cfx by log %+v error", log)
+			}
+			if filled {
+				continue
+			}
+		}
+
+		// tokens created or destroyed by erc777 Minted or Burned event
+		filled, err := tc.fillTxDictByMintOrBurnEvent(txDict, &log, counter, sn)
+		if err != nil {
+			return errors.Wrapf(err, "fill mint or burn by log %+v error", log)
+		}
+		if filled {
+			continue
+		}
+
+		// swaps and liquidity changes of DEX pairs are summarized after all logs are decoded
+		dexParams, err := tc.decoder.DecodeDexEvent(&log)
+		if err != nil {
+			return errors.Wrapf(err, "decode DEX event by log %+v error", log)
+		}
+		if dexParams != nil {
+			// the contract emitting event with the same signature of pair is decoded as others if it is not a pair
+			if token0, token1, ok := tc.getPairTokens(log.Address); ok {
+				dexEvents = append(dexEvents, dexEvent{pair: log.Address, token0: token0, token1: token1, params: dexParams})
+				continue
+			}
+		}
+
 		// fmt.Println("start decode log")
 		eventParams, err := tc.decoder.DecodeEvent(&log)
 		if err != nil {
@@ -257,9 +382,14 @@ func (tc *TxDictConverter) fillTxDictByTxReceipt(txDict *richtypes.TxDict, recei
 		if eventParams != nil {
 			// fmt.Printf("gen input and output by eventParams %+v", eventParams)
 			// fmt.Printf("before getTokenByIdentifier, tc:%+v,log:%+v,receipt.To:%v", tc, log, receipt.To)
-			tokenInfo := tc.getTokenByIdentifier(&log, *receipt.To)
+			// the token is the contract emitting event, which maybe not the contract called by transaction
+			tokenIdentifier := log.Address
+			tokenInfo := tc.getTokenByIdentifier(&log, tokenIdentifier)
+			if tokenInfo == nil {
+				tokenInfo = &richtypes.Token{}
+			}
 			// get amount or value, if nil that means not token transfer
-			amount, err := getValueOrAmount(eventParams)
+			amount, tokenID, err := getTransferValue(eventParams)
 			if err != nil {
 				return errors.Wrapf(err, "Failed to get value of log %+v", eventParams)
 			}
@@ -275,45 +405,75 @@ func (tc *TxDictConverter) fillTxDictByTxReceipt(txDict *richtypes.TxDict, recei
 				return errors.Wrapf(err, "Failed to create address by %v", paramsV.FieldByName("To"))
 			}
 
+			// mint and burn of wrapped CFX are filled by Deposit, Withdrawal, Minted or Burned event
+			if tc.isWrappedCFX(&log.Address) &&
+				(from.MustGetCommonAddress() == (common.Address{}) || to.MustGetCommonAddress() == (common.Address{})) {
+				continue
+			}
+
 			//fill to txdict inputs and outputs
 			input := richtypes.TxUnit{
 				Value:           amount,
 				Address:         &from,
 				Sn:              *sn,
 				TokenCode:       tokenInfo.TokenSymbol,
-				TokenIdentifier: receipt.To,
+				TokenIdentifier: &tokenIdentifier,
 				TokenDecimal:    tokenInfo.TokenDecimal,
+				TokenId:         tokenID,
 			}
 			output := richtypes.TxUnit{
 				Value:           amount,
 				Address:         &to,
 				Sn:              *sn,
 				TokenCode:       tokenInfo.TokenSymbol,
-				TokenIdentifier: receipt.To,
+				TokenIdentifier: &tokenIdentifier,
 				TokenDecimal:    tokenInfo.TokenDecimal,
+				TokenId:         tokenID,
+			}
+
+			setMintOrBurnKind(&input, &output)
+			if input.Kind != "" && !counter.add(true, &input, &output) {
+				continue
 			}
+
 			txDict.Inputs = append(txDict.Inputs, input)
 			txDict.Outputs = append(txDict.Outputs, output)
 			(*sn)++
 		}
 	}
+
+	tc.fillTxDictByDexEvents(&txDict.TxDictBase, dexEvents)
 	return nil
 }
 
 // getTokenByIdentifier ...
 func (tc *TxDictConverter) getTokenByIdentifier(log *types.Log, contractAddress types.Address) *richtypes.Token {
+	// token info could not be got without rich client
+	if tc.richClient == nil {
+		return nil
+	}
 
 	if _, ok := tc.tokenCache[contractAddress.String()]; !ok {
 
 		concrete, err := tc.decoder.GetTransferEventMatchedConcrete(log)
 
-		tc.mutex.Lock()
 		if err != nil || concrete == nil {
+			tc.mutex.Lock()
 			tc.tokenCache[contractAddress.String()] = nil
 			tc.mutex.Unlock()
 			return nil
 		}
-		tc.mutex.Unlock()
+
+		return tc.getTokenByConcrete(concrete, contractAddress)
+	}
+
+	return tc.tokenCache[contractAddress.String()]
+}
+
+// getTokenByConcrete gets token name, symbol and decimals of contractAddress by calling methods of the matched concrete contract
+func (tc *TxDictConverter) getTokenByConcrete(concrete *richtypes.ContractElemConcrete, contractAddress types.Address) *richtypes.Token {
+
+	if _, ok := tc.tokenCache[contractAddress.String()]; !ok {
 
 		realContract := sdk.Contract{ABI: concrete.Contract.ABI, Client: tc.richClient.GetClient(), Address: &contractAddress}
 		var (
@@ -324,7 +484,7 @@ func (tc *TxDictConverter) getTokenByIdentifier(log *types.Log, contractAddress
 
 		// currently there is no confusion methods exist, so call by the method name directly,
 		// if not we need use type_map file to identify the exactly contract type and method type.
-		err = realContract.Call(nil, &name, "name")
+		realContract.Call(nil, &name, "name")
 
 		// the contract maybe not completely standard, so it is legal without name
 		// TODO: Add --dubeg flag for print logs
@@ -332,7 +492,7 @@ func (tc *TxDictConverter) getTokenByIdentifier(log *types.Log, contractAddress
 		// 	fmt.Printf("call function 'name' of contract address %+v which is %v error: %v\n\n", realContract.Address, concrete.ContractType, err)
 		// }
 
-		err = realContract.Call(nil, &symbol, "symbol")
+		realContract.Call(nil, &symbol, "symbol")
 
 		// the contract maybe not completely standard, so it is legal without symbol
 		// TODO: Add --dubeg flag for print logs
@@ -341,7 +501,7 @@ func (tc *TxDictConverter) getTokenByIdentifier(log *types.Log, contractAddress
 		// }
 
 		if _, ok := realContract.ABI.Methods["decimals"]; ok {
-			err = realContract.Call(nil, &decimals, "decimals")
+			realContract.Call(nil, &decimals, "decimals")
 
 			// TODO: Add --dubeg flag for print logs
 			// the contract maybe not completely standard, so it is legal without decimals
@@ -397,51 +557,174 @@ func (tc *TxDictConverter) ConvertByUnsignedTransaction(tx *types.UnsignedTransa
 	}
 
 	// decode tx.Data to token transfer
-	contractDecoder, err := decoder.NewContractDecoder()
-	if err != nil {
-		// fmt.Printf("NewContractDecoder err %v\n", err)
+	if tx.To == nil || len(tx.Data) < 4 {
 		return txDictBase
 	}
 
-	if tx.Data == nil || len(tx.Data) < 4 {
+	if decoder.IsCrossSpaceCall(tx.To) {
+		tc.fillTxDictByCrossSpaceFunction(txDictBase, tx)
 		return txDictBase
 	}
 
-	funcParams, err := contractDecoder.DecodeFunction(tx.Data)
-	if err != nil {
+	// transfer of wrapped CFX is decoded as other tokens
+	if tc.isWrappedCFX(tx.To) && tc.fillTxDictByWrappedCFXFunction(txDictBase, tx) {
+		return txDictBase
+	}
+
+	if decoder.IsAdminControl(tx.To) || decoder.IsSponsorWhitelistControl(tx.To) {
+		txDictBase.Extra.Label, _ = tc.getInternalContractLabel(tx)
+		return txDictBase
+	}
+
+	if decoder.IsStaking(tx.To) {
+		sn := uint64(len(txDictBase.Inputs))
+		tc.fillTxDictByStakingFunction(txDictBase, tx.From, tx.Data, &sn)
+		return txDictBase
+	}
+
+	// the tokens swapped by DEX router are summarized as the transfers happen in pairs
+	if tc.isDexRouter(tx.To) && tc.fillTxDictByDexRouterFunction(txDictBase, tx) {
+		return txDictBase
+	}
+
+	concrete := tc.getFunctionConcrete(tx.Data, *tx.To)
+	if concrete == nil {
+		return txDictBase
+	}
+
+	funcParams, err := concrete.DecodeFunction(tx.Data)
+	if err != nil || funcParams == nil {
 		// fmt.Printf("decode function err %v\n", err)
 		return txDictBase
 	}
 
 	// fmt.Printf("decode function done %+v\n\n", funcParams)
-	amount, err := getValueOrAmount(funcParams)
+	amount, tokenID, err := getTransferValue(funcParams)
 	// fmt.Printf("get amount done %+v,err:%v\n\n", amount, err)
-	if err == nil {
-		paramsV := reflect.ValueOf(funcParams).Elem()
-		to := paramsV.FieldByName("To").Interface().(common.Address)
-
-		txDictBase.Inputs = append(txDictBase.Inputs, richtypes.TxUnit{
-			Value:           amount,
-			Address:         tx.From,
-			Sn:              1,
-			TokenIdentifier: tx.To,
-		},
-		)
-		txDictBase.Outputs = append(txDictBase.Outputs, richtypes.TxUnit{
-			Value:           amount,
-			Address:         helper.MustNewCfxAddressPtr(&to, tc.networkID),
-			Sn:              1,
-			TokenIdentifier: tx.To,
-		})
+	if err != nil {
+		return txDictBase
+	}
+
+	paramsV := reflect.ValueOf(funcParams).Elem()
+	to := paramsV.FieldByName("To").Interface().(common.Address)
+
+	// token holder of transferFrom, operatorSend and safeTransferFrom is specified by params
+	from := tx.From
+	if fromV := paramsV.FieldByName("From"); fromV.IsValid() {
+		_from := fromV.Interface().(common.Address)
+		from = helper.MustNewCfxAddressPtr(&_from, tc.networkID)
+	}
+
+	tokenInfo := &richtypes.Token{}
+	if tc.richClient != nil {
+		if _tokenInfo := tc.getTokenByConcrete(concrete, *tx.To); _tokenInfo != nil {
+			tokenInfo = _tokenInfo
+		}
 	}
 
+	txDictBase.Inputs = append(txDictBase.Inputs, richtypes.TxUnit{
+		Value:           amount,
+		Address:         from,
+		Sn:              1,
+		TokenCode:       tokenInfo.TokenSymbol,
+		TokenIdentifier: tx.To,
+		TokenDecimal:    tokenInfo.TokenDecimal,
+		TokenId:         tokenID,
+	},
+	)
+	txDictBase.Outputs = append(txDictBase.Outputs, richtypes.TxUnit{
+		Value:           amount,
+		Address:         helper.MustNewCfxAddressPtr(&to, tc.networkID),
+		Sn:              1,
+		TokenCode:       tokenInfo.TokenSymbol,
+		TokenIdentifier: tx.To,
+		TokenDecimal:    tokenInfo.TokenDecimal,
+		TokenId:         tokenID,
+	})
+
 	return txDictBase
 }
 
-func getValueOrAmount(funcOrEventParams interface{}) (*big.Int, error) {
+// getFunctionConcrete returns the function concrete matched with data, the signature shared by contract types,
+// such as transferFrom of erc20 and erc721, is resolved by the type of contract. The arguments of shared signature
+// are encoded in the same way, so the first concrete, which is erc20 for transferFrom, is used if the type is unknown.
+func (tc *TxDictConverter) getFunctionConcrete(data []byte, contractAddress types.Address) *richtypes.ContractElemConcrete {
+	concretes := tc.decoder.GetFunctionMatchedConcretes(data)
+	if len(concretes) == 0 {
+		return nil
+	}
+	if len(concretes) == 1 {
+		return &concretes[0]
+	}
+
+	contractType := tc.getContractType(contractAddress)
+	for i := range concretes {
+		if concretes[i].ContractType == contractType {
+			return &concretes[i]
+		}
+	}
+	return &concretes[0]
+}
+
+// getContractType returns contract type by verified ABI of contract, or by ERC165 supportsInterface on chain
+// if the ABI is not verified, in which case the contract is regarded as erc20 if it doesn't support erc721.
+// It returns UNKNOWN if the type could not be settled.
+func (tc *TxDictConverter) getContractType(contractAddress types.Address) richtypes.ContractType {
+	// contract type could not be got without rich client
+	if tc.richClient == nil {
+		return richtypes.UNKNOWN
+	}
+
+	tc.mutex.Lock()
+	contractType, ok := tc.contractTypes[contractAddress.String()]
+	tc.mutex.Unlock()
+	if ok {
+		return contractType
+	}
+
+	contractType = richtypes.UNKNOWN
+	if contractInfo, err := tc.richClient.GetContractInfo(contractAddress, true, false); err == nil && contractInfo != nil {
+		contractType = contractInfo.GetContractTypeByABI()
+	}
+
+	if contractType == richtypes.UNKNOWN {
+		contract, err := tc.richClient.GetClient().GetContract([]byte(abi.GetABI(richtypes.ERC721)), &contractAddress)
+		if err != nil {
+			return richtypes.UNKNOWN
+		}
+
+		// contracts without supportsInterface revert the call
+		var isERC721 bool
+		if err := contract.Call(nil, &isERC721, "supportsInterface", richconstants.Erc721InterfaceID); err != nil && !isCallReverted(err) {
+			return richtypes.UNKNOWN
+		}
+		contractType = richtypes.ERC20
+		if isERC721 {
+			contractType = richtypes.ERC721
+		}
+	}
+
+	tc.mutex.Lock()
+	tc.contractTypes[contractAddress.String()] = contractType
+	tc.mutex.Unlock()
+	return contractType
+}
+
+// isCallReverted returns true if the contract call is rejected by the node, such as reverted by the contract,
+// rather than failed to request.
+func isCallReverted(err error) bool {
+	_, ok := errors.Cause(err).(rpc.Error)
+	return ok
+}
+
+// getTransferValue returns transfered value of function or event params, the value is 1 and tokenID is the transfered token id for non-fungible token.
+func getTransferValue(funcOrEventParams interface{}) (value *big.Int, tokenID *big.Int, err error) {
 
-	// fmt.Printf("getValueOrAmount of %#v\n\n", funcOrEventParams)
+	// fmt.Printf("getTransferValue of %#v\n\n", funcOrEventParams)
 	reflectValue := reflect.ValueOf(funcOrEventParams)
+	if reflectValue.Kind() != reflect.Ptr || reflectValue.IsNil() {
+		return nil, nil, fmt.Errorf("params %+v is not a pointer of struct", funcOrEventParams)
+	}
 	// fmt.Printf("reflectValue is %+v\n\n", reflectValue)
 
 	paramsV := reflectValue.Elem()
@@ -450,9 +733,14 @@ func getValueOrAmount(funcOrEventParams interface{}) (*big.Int, error) {
 		fieldV = paramsV.FieldByName("Amount")
 	}
 	if (fieldV == reflect.Value{}) {
-		return nil, fmt.Errorf("not found field amout or value from %+v", funcOrEventParams)
+		if tokenIDV := paramsV.FieldByName("TokenId"); (tokenIDV != reflect.Value{}) {
+			return big.NewInt(1), tokenIDV.Interface().(*big.Int), nil
+		}
+	}
+	if (fieldV == reflect.Value{}) {
+		return nil, nil, fmt.Errorf("not found field amout or value from %+v", funcOrEventParams)
 	}
 	tokenValue := fieldV.Interface().(*big.Int)
 
-	return tokenValue, nil
+	return tokenValue, nil, nil
 }
@@ -0,0 +1,202 @@
+package walletsdk
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	sdk "github.com/Conflux-Chain/go-conflux-sdk"
+	walletinterface "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/interface"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/resource/contract/abi"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// converterClientMock mocks methods used by getTokenByConcrete and getContractType,
+// contracts in supportsInterface answer whether they support erc721 and other calls fail.
+type converterClientMock struct {
+	sdk.ClientOperator
+	supportsInterface map[string]bool
+}
+
+// callRevertError is the rpc error of a reverted call
+type callRevertError struct{}
+
+func (e callRevertError) Error() string  { return "execution reverted" }
+func (e callRevertError) ErrorCode() int { return -32015 }
+
+func (c *converterClientMock) GetContract(abiJSON []byte, deployedAt *types.Address) (*sdk.Contract, error) {
+	contract, err := sdk.NewContract(abiJSON, nil, deployedAt)
+	if err != nil {
+		return nil, err
+	}
+	contract.Client = c
+	return contract, nil
+}
+
+func (c *converterClientMock) Call(request types.CallRequest, epoch *types.Epoch) (hexutil.Bytes, error) {
+	isERC721, ok := c.supportsInterface[request.To.String()]
+	if !ok || request.Data == nil || !strings.HasPrefix(*request.Data, "0x01ffc9a7") {
+		return nil, callRevertError{}
+	}
+	result := make([]byte, 32)
+	if isERC721 {
+		result[31] = 1
+	}
+	return result, nil
+}
+
+// converterRichClientMock mocks methods used by ConvertByUnsignedTransaction
+type converterRichClientMock struct {
+	walletinterface.RichClientOperator
+	contracts         map[string]*richtypes.Contract
+	supportsInterface map[string]bool
+}
+
+func (rc *converterRichClientMock) GetClient() sdk.ClientOperator {
+	return &converterClientMock{supportsInterface: rc.supportsInterface}
+}
+
+func (rc *converterRichClientMock) GetContractInfo(contractAddress types.Address, needABI, needIcon bool) (*richtypes.Contract, error) {
+	if contract, ok := rc.contracts[contractAddress.String()]; ok {
+		return contract, nil
+	}
+	return nil, richtypes.ErrNotFound
+}
+
+func TestConvertTransferFromUnsignedTransaction(t *testing.T) {
+	from := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+	holder := cfxaddress.MustNewFromHex("0x1195c6b43264113a75719202716cc763bacb7da5", cfxaddress.NetowrkTypeMainnetID)
+	to := cfxaddress.MustNewFromHex("0x160ebef20c1f739957bf9eecd040bce699cc42c6", cfxaddress.NetowrkTypeMainnetID)
+	erc20Token := cfxaddress.MustNewFromHex("0x8b8689c7f3014a4d86e4d1d0daaf74a47f5e0f27", cfxaddress.NetowrkTypeMainnetID)
+	erc721Token := cfxaddress.MustNewFromHex("0x8d545118d91c027c805c552f63a5c00a20ae6aca", cfxaddress.NetowrkTypeMainnetID)
+	unverifiedERC20 := cfxaddress.MustNewFromHex("0x8d7df9316faa0586e175b5e6d03c6bda76e3d950", cfxaddress.NetowrkTypeMainnetID)
+	unverifiedERC721 := cfxaddress.MustNewFromHex("0x8a5c2e6b2f1d6e0ec5b9a2b7d0c3f4e5a6b7c8d9", cfxaddress.NetowrkTypeMainnetID)
+	unverifiedERC165 := cfxaddress.MustNewFromHex("0x8b6d3f7c3a2e7f1fd6cab3c8e1d4a5f6b7c8d9ea", cfxaddress.NetowrkTypeMainnetID)
+
+	converter, err := NewTxDictConverter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	converter.richClient = &converterRichClientMock{contracts: map[string]*richtypes.Contract{
+		erc20Token.String():  {ABI: abi.GetABI(richtypes.ERC20)},
+		erc721Token.String(): {ABI: abi.GetABI(richtypes.ERC721)},
+	}, supportsInterface: map[string]bool{
+		unverifiedERC721.String(): true,
+		unverifiedERC165.String(): false,
+	}}
+
+	erc721, err := sdk.NewContract([]byte(abi.GetABI(richtypes.ERC721)), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := erc721.GetData("transferFrom", holder.MustGetCommonAddress(), to.MustGetCommonAddress(), big.NewInt(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := new(types.UnsignedTransaction)
+	tx.From = &from
+	tx.Value = types.NewBigInt(0)
+	tx.Data = data
+
+	// erc721 transferFrom moves the token specified by token id
+	tx.To = &erc721Token
+	txDictBase := converter.ConvertByUnsignedTransaction(tx)
+	if len(txDictBase.Outputs) != 2 {
+		t.Fatalf("expect cfx and token units, actual: %+v", txDictBase.Outputs)
+	}
+	input, output := txDictBase.Inputs[1], txDictBase.Outputs[1]
+	if input.Address.String() != holder.String() || output.Address.String() != to.String() ||
+		output.Value.Cmp(big.NewInt(1)) != 0 || output.TokenId == nil || output.TokenId.Cmp(big.NewInt(7)) != 0 {
+		t.Errorf("expect token 7 from %v to %v, actual input: %+v, output: %+v", holder, to, input, output)
+	}
+
+	// erc20 transferFrom with the same signature moves the amount
+	tx.To = &erc20Token
+	txDictBase = converter.ConvertByUnsignedTransaction(tx)
+	if len(txDictBase.Outputs) != 2 {
+		t.Fatalf("expect cfx and token units, actual: %+v", txDictBase.Outputs)
+	}
+	output = txDictBase.Outputs[1]
+	if output.Value.Cmp(big.NewInt(7)) != 0 || output.TokenId != nil {
+		t.Errorf("expect 7 tokens to %v, actual: %+v", to, output)
+	}
+
+	// the type of contract without verified ABI is settled by erc165 on chain, and it is erc20 if supportsInterface reverts
+	for _, c := range []struct {
+		token   types.Address
+		value   *big.Int
+		tokenID *big.Int
+	}{
+		{unverifiedERC721, big.NewInt(1), big.NewInt(7)},
+		{unverifiedERC165, big.NewInt(7), nil},
+		{unverifiedERC20, big.NewInt(7), nil},
+	} {
+		token := c.token
+		tx.To = &token
+		txDictBase = converter.ConvertByUnsignedTransaction(tx)
+		if len(txDictBase.Outputs) != 2 {
+			t.Fatalf("expect cfx and token units of %v, actual: %+v", token, txDictBase.Outputs)
+		}
+		input, output = txDictBase.Inputs[1], txDictBase.Outputs[1]
+		if input.Address.String() != holder.String() || output.Value.Cmp(c.value) != 0 ||
+			(c.tokenID == nil) != (output.TokenId == nil) || (c.tokenID != nil && output.TokenId.Cmp(c.tokenID) != 0) {
+			t.Errorf("expect value %v and token id %v of %v, actual input: %+v, output: %+v", c.value, c.tokenID, token, input, output)
+		}
+	}
+}
+
+func TestConvertTransferEventOfCalledContract(t *testing.T) {
+	converter, err := NewTxDictConverter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+	to := cfxaddress.MustNewFromHex("0x160ebef20c1f739957bf9eecd040bce699cc42c6", cfxaddress.NetowrkTypeMainnetID)
+	router := cfxaddress.MustNewFromHex("0x80ae6a88ce3351e9f729e8199f2871ba786ad7c5", cfxaddress.NetowrkTypeMainnetID)
+	token := cfxaddress.MustNewFromHex("0x8b8689c7f3014a4d86e4d1d0daaf74a47f5e0f27", cfxaddress.NetowrkTypeMainnetID)
+
+	erc20, err := sdk.NewContract([]byte(abi.GetABI(richtypes.ERC20)), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transferData, err := erc20.ABI.Events["Transfer"].Inputs.NonIndexed().Pack(big.NewInt(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the token transfer happens in the token contract called by router
+	txDict := new(richtypes.TxDict)
+	receipt := &types.TransactionReceipt{
+		To: &router,
+		Logs: []types.Log{{
+			Address: token,
+			Topics: []types.Hash{
+				types.Hash(erc20.ABI.Events["Transfer"].ID.Hex()),
+				types.Hash(common.BytesToHash(from.MustGetCommonAddress().Bytes()).Hex()),
+				types.Hash(common.BytesToHash(to.MustGetCommonAddress().Bytes()).Hex()),
+			},
+			Data: transferData,
+		}},
+	}
+
+	sn := uint64(1)
+	if err := converter.fillTxDictByTxReceipt(txDict, receipt, &sn); err != nil {
+		t.Fatal(err)
+	}
+	if len(txDict.Outputs) != 1 {
+		t.Fatalf("expect 1 token transfer, actual: %+v", txDict.Outputs)
+	}
+	input, output := txDict.Inputs[0], txDict.Outputs[0]
+	if input.TokenIdentifier.String() != token.String() || output.TokenIdentifier.String() != token.String() {
+		t.Errorf("expect token identifier %v emitting the event, actual input: %v, output: %v", token, input.TokenIdentifier, output.TokenIdentifier)
+	}
+	if input.Address.String() != from.String() || output.Address.String() != to.String() || output.Value.Cmp(big.NewInt(100)) != 0 {
+		t.Errorf("expect 100 tokens from %v to %v, actual input: %+v, output: %+v", from, to, input, output)
+	}
+}
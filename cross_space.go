@@ -0,0 +1,97 @@
+package walletsdk
+
+import (
+	"math/big"
+
+	richconstants "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/constants"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/helper"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/constants"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// fillTxDictByCrossSpaceEvent fills cfx moved between core space and espace according to Call, Create and Withdraw events of CrossSpaceCall
+func (tc *TxDictConverter) fillTxDictByCrossSpaceEvent(txDictBase *richtypes.TxDictBase, log *types.Log, sn *uint64) error {
+	eventParams, err := tc.decoder.DecodeCrossSpaceEvent(log)
+	if err != nil {
+		return err
+	}
+
+	switch params := eventParams.(type) {
+	case *richtypes.CrossSpaceCallEventParams:
+		tc.fillCrossSpaceToESpace(txDictBase, common.Address(params.Sender), common.Address(params.Receiver), params.Value, sn)
+	case *richtypes.CrossSpaceCreateEventParams:
+		tc.fillCrossSpaceToESpace(txDictBase, common.Address(params.Sender), common.Address(params.ContractAddress), params.Value, sn)
+	case *richtypes.CrossSpaceWithdrawEventParams:
+		tc.fillCrossSpaceFromESpace(txDictBase, common.Address(params.Sender), params.Receiver, params.Value, sn)
+	}
+	return nil
+}
+
+// fillTxDictByCrossSpaceFunction fills cfx moved between core space and espace according to data of unsigned transaction sent to CrossSpaceCall
+func (tc *TxDictConverter) fillTxDictByCrossSpaceFunction(txDictBase *richtypes.TxDictBase, tx *types.UnsignedTransaction) {
+	funcParams, err := tc.decoder.DecodeCrossSpaceFunction(tx.Data)
+	if err != nil || tx.From == nil {
+		return
+	}
+
+	sn := uint64(len(txDictBase.Inputs))
+	switch params := funcParams.(type) {
+	case *richtypes.CrossSpaceTransferEVMFunctionParams:
+		tc.fillCrossSpaceToESpace(txDictBase, tx.From.MustGetCommonAddress(), params.To, tx.Value.ToInt(), &sn)
+	case *richtypes.CrossSpaceWithdrawFunctionParams:
+		from := tx.From.MustGetCommonAddress()
+		tc.fillCrossSpaceFromESpace(txDictBase, helper.GetMappedESpaceAddress(from), from, params.Value, &sn)
+	}
+}
+
+// fillCrossSpaceToESpace marks the cfx output to CrossSpaceCall with espace receiver,
+// or appends a new movement if there is no such output, such as calling CrossSpaceCall by contract.
+func (tc *TxDictConverter) fillCrossSpaceToESpace(txDictBase *richtypes.TxDictBase, sender, eSpaceReceiver common.Address, value *big.Int, sn *uint64) {
+	if value == nil || value.Sign() == 0 {
+		return
+	}
+
+	for i := range txDictBase.Outputs {
+		output := &txDictBase.Outputs[i]
+		if output.TokenIdentifier == nil && output.ESpaceAddress == nil && output.Value != nil && output.Value.Cmp(value) == 0 &&
+			output.Address != nil && output.Address.MustGetCommonAddress() == richconstants.CrossSpaceCallHexAddress {
+			output.ESpaceAddress = &eSpaceReceiver
+			return
+		}
+	}
+
+	input := tc.newCFXUnit(helper.MustNewCfxAddressPtr(&sender, tc.networkID), value, *sn)
+	output := tc.newCFXUnit(helper.MustNewCfxAddressPtr(&richconstants.CrossSpaceCallHexAddress, tc.networkID), value, *sn)
+	output.ESpaceAddress = &eSpaceReceiver
+	(*sn)++
+
+	txDictBase.Inputs = append(txDictBase.Inputs, input)
+	txDictBase.Outputs = append(txDictBase.Outputs, output)
+}
+
+// fillCrossSpaceFromESpace appends the movement of cfx withdrawn from espace mapped address to core space receiver
+func (tc *TxDictConverter) fillCrossSpaceFromESpace(txDictBase *richtypes.TxDictBase, eSpaceSender, receiver common.Address, value *big.Int, sn *uint64) {
+	if value == nil || value.Sign() == 0 {
+		return
+	}
+
+	input := tc.newCFXUnit(helper.MustNewCfxAddressPtr(&richconstants.CrossSpaceCallHexAddress, tc.networkID), value, *sn)
+	input.ESpaceAddress = &eSpaceSender
+	output := tc.newCFXUnit(helper.MustNewCfxAddressPtr(&receiver, tc.networkID), value, *sn)
+	(*sn)++
+
+	txDictBase.Inputs = append(txDictBase.Inputs, input)
+	txDictBase.Outputs = append(txDictBase.Outputs, output)
+}
+
+func (tc *TxDictConverter) newCFXUnit(address *types.Address, value *big.Int, sn uint64) richtypes.TxUnit {
+	return richtypes.TxUnit{
+		Value:        new(big.Int).Set(value),
+		Address:      address,
+		Sn:           sn,
+		TokenCode:    constants.CFXSymbol,
+		TokenDecimal: constants.CFXDecimal,
+	}
+}
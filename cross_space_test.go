@@ -0,0 +1,70 @@
+package walletsdk
+
+import (
+	"math/big"
+	"testing"
+
+	sdk "github.com/Conflux-Chain/go-conflux-sdk"
+	richconstants "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/constants"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/helper"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/resource/contract/abi"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestConvertCrossSpaceUnsignedTransaction(t *testing.T) {
+	converter, err := NewTxDictConverter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	crossSpaceCall, err := sdk.NewContract([]byte(abi.GetABI(richtypes.CROSSSPACE)), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+	to := cfxaddress.MustNewFromCommon(richconstants.CrossSpaceCallHexAddress, cfxaddress.NetowrkTypeMainnetID)
+	eSpaceReceiver := common.HexToAddress("0x160ebef20c1f739957bf9eecd040bce699cc42c6")
+
+	// transfer to espace
+	data, err := crossSpaceCall.GetData("transferEVM", [20]byte(eSpaceReceiver))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := new(types.UnsignedTransaction)
+	tx.From = &from
+	tx.To = &to
+	tx.Value = types.NewBigInt(1000)
+	tx.Data = data
+
+	txDictBase := converter.ConvertByUnsignedTransaction(tx)
+	if len(txDictBase.Outputs) != 1 || txDictBase.Outputs[0].ESpaceAddress == nil || *txDictBase.Outputs[0].ESpaceAddress != eSpaceReceiver {
+		t.Fatalf("expect output with espace address %v, actual: %+v", eSpaceReceiver.Hex(), txDictBase.Outputs)
+	}
+
+	// withdraw from espace mapped address
+	data, err = crossSpaceCall.GetData("withdrawFromMapped", big.NewInt(500))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Value = types.NewBigInt(0)
+	tx.Data = data
+
+	txDictBase = converter.ConvertByUnsignedTransaction(tx)
+	if len(txDictBase.Inputs) != 2 {
+		t.Fatalf("expect 2 inputs, actual: %+v", txDictBase.Inputs)
+	}
+
+	mapped := helper.GetMappedESpaceAddress(from.MustGetCommonAddress())
+	input, output := txDictBase.Inputs[1], txDictBase.Outputs[1]
+	if input.ESpaceAddress == nil || *input.ESpaceAddress != mapped || input.Value.Cmp(big.NewInt(500)) != 0 {
+		t.Errorf("expect input from espace mapped address %v, actual: %+v", mapped.Hex(), input)
+	}
+	if output.Address.String() != from.String() {
+		t.Errorf("expect output to %v, actual: %v", from, output.Address)
+	}
+}
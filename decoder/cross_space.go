@@ -0,0 +1,75 @@
+package decoder
+
+import (
+	"math/big"
+
+	richconstants "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/constants"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// IsCrossSpaceCall returns true if address is the CrossSpaceCall internal contract
+func IsCrossSpaceCall(address *types.Address) bool {
+	return address != nil && address.MustGetCommonAddress() == richconstants.CrossSpaceCallHexAddress
+}
+
+// DecodeCrossSpaceEvent decodes log of CrossSpaceCall into instance of Call, Create or Withdraw event params,
+// it returns nil if log is not one of them.
+func (cd *ContractDecoder) DecodeCrossSpaceEvent(log *types.Log) (eventParmsPtr interface{}, err error) {
+	if !IsCrossSpaceCall(&log.Address) || len(log.Topics) == 0 {
+		return nil, nil
+	}
+
+	event, err := crossSpaceContract.ABI.EventByID(*log.Topics[0].ToCommonHash())
+	if err != nil {
+		return nil, nil
+	}
+
+	switch event.RawName {
+	case "Call":
+		eventParmsPtr = &richtypes.CrossSpaceCallEventParams{}
+	case "Create":
+		eventParmsPtr = &richtypes.CrossSpaceCreateEventParams{}
+	case "Withdraw":
+		eventParmsPtr = &richtypes.CrossSpaceWithdrawEventParams{}
+	default:
+		return nil, nil
+	}
+
+	if err = crossSpaceContract.DecodeEvent(eventParmsPtr, event.RawName, *log); err != nil {
+		return nil, errors.Wrapf(err, "decode event %v of CrossSpaceCall error", event.RawName)
+	}
+	return eventParmsPtr, nil
+}
+
+// DecodeCrossSpaceFunction decodes data of CrossSpaceCall into instance of transferEVM, callEVM or withdrawFromMapped params,
+// it returns nil if data is not one of them.
+func (cd *ContractDecoder) DecodeCrossSpaceFunction(data []byte) (functionParmsPtr interface{}, err error) {
+	if len(data) < 4 {
+		return nil, nil
+	}
+
+	method, err := crossSpaceContract.ABI.MethodById(data[:4])
+	if err != nil {
+		return nil, nil
+	}
+
+	result, err := method.Inputs.UnpackValues(data[4:])
+	if err != nil {
+		return nil, errors.Wrapf(err, "unpack arguments of method %v error", method.Sig)
+	}
+
+	switch method.RawName {
+	case "transferEVM":
+		to := result[0].([20]byte)
+		return &richtypes.CrossSpaceTransferEVMFunctionParams{To: common.Address(to)}, nil
+	case "callEVM":
+		to := result[0].([20]byte)
+		return &richtypes.CrossSpaceTransferEVMFunctionParams{To: common.Address(to), Data: result[1].([]byte)}, nil
+	case "withdrawFromMapped":
+		return &richtypes.CrossSpaceWithdrawFunctionParams{Value: result[0].(*big.Int)}, nil
+	}
+	return nil, nil
+}
@@ -2,6 +2,9 @@ package decoder
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
+	"sync"
 
 	sdk "github.com/Conflux-Chain/go-conflux-sdk"
 	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/resource/contract/abi"
@@ -22,6 +25,28 @@ type ContractDecoder struct {
 
 var contractElemIdToConcreteDicCache map[string][]richtypes.ContractElemConcrete
 
+// contractElemIdToConcreteDicOnce makes sure the dic and the contracts of registry are created only once,
+// as decoders are created by goroutines concurrently.
+var (
+	contractElemIdToConcreteDicOnce sync.Once
+	contractElemIdToConcreteDicErr  error
+)
+
+// standardContracts contains contracts of ABIs in registry, which are sorted by contract type
+var standardContracts []*sdk.Contract
+
+// crossSpaceContract is the contract of CrossSpaceCall ABI in registry
+var crossSpaceContract *sdk.Contract
+
+// stakingContract is the contract of Staking ABI in registry
+var stakingContract *sdk.Contract
+
+// wrappedCFXContract is the contract of wrapped CFX ABI in registry
+var wrappedCFXContract *sdk.Contract
+
+// dexContract is the contract of DEX ABI in registry
+var dexContract *sdk.Contract
+
 // NewContractDecoder creates an EventDecoder instance
 func NewContractDecoder() (*ContractDecoder, error) {
 	dic, err := createContractElemIdToConcreteDic()
@@ -36,20 +61,45 @@ func NewContractDecoder() (*ContractDecoder, error) {
 
 // createContractElemIdToConcreteDic creat mappings for contract element id, containts event id or function signature, to element concrete information.
 func createContractElemIdToConcreteDic() (map[string][]richtypes.ContractElemConcrete, error) {
-	if contractElemIdToConcreteDicCache != nil {
-		return contractElemIdToConcreteDicCache, nil
-	}
+	contractElemIdToConcreteDicOnce.Do(func() {
+		contractElemIdToConcreteDicErr = buildContractElemIdToConcreteDic()
+	})
+	return contractElemIdToConcreteDicCache, contractElemIdToConcreteDicErr
+}
 
+// buildContractElemIdToConcreteDic builds the dic and the contracts of ABIs in registry, it should be called only once.
+func buildContractElemIdToConcreteDic() error {
 	contractElemIdToConcreteDicCache = make(map[string][]richtypes.ContractElemConcrete)
+	standardContracts = make([]*sdk.Contract, 0, len(abi.ABIJsonDic))
+
+	contractTypes := make([]richtypes.ContractType, 0, len(abi.ABIJsonDic))
+	for contractType := range abi.ABIJsonDic {
+		contractTypes = append(contractTypes, contractType)
+	}
+	sort.Slice(contractTypes, func(i, j int) bool { return contractTypes[i] < contractTypes[j] })
 
-	for contractType, abiJSON := range abi.ABIJsonDic {
+	for _, contractType := range contractTypes {
+		abiJSON := abi.ABIJsonDic[contractType]
 		// get contract
 
 		var client *sdk.Client
 		contractAddress := cfxaddress.MustNewFromCommon(constants.ZeroAddress)
 		contract, err := client.GetContract([]byte(abiJSON), &contractAddress)
 		if err != nil {
-			return nil, errors.Wrapf(err, "unmarshal json {%+v} to ABI error", abiJSON)
+			return errors.Wrapf(err, "unmarshal json {%+v} to ABI error", abiJSON)
+		}
+		standardContracts = append(standardContracts, contract)
+		if contractType == richtypes.CROSSSPACE {
+			crossSpaceContract = contract
+		}
+		if contractType == richtypes.STAKING {
+			stakingContract = contract
+		}
+		if contractType == richtypes.WCFX {
+			wrappedCFXContract = contract
+		}
+		if contractType == richtypes.DEX {
+			dexContract = contract
 		}
 
 		elemConcretes := []richtypes.ContractElemConcrete{}
@@ -90,15 +140,14 @@ func createContractElemIdToConcreteDic() (map[string][]richtypes.ContractElemCon
 					}
 
 					contractElemIdToConcreteDicCache[sign] = append(contractElemIdToConcreteDicCache[sign], contrete)
-					// event name in contract is unique, so jump out of loop
-					break
+					// function maybe overloaded, such as erc721 safeTransferFrom, so register all of them
 				}
 			}
 
 		}
 	}
 
-	return contractElemIdToConcreteDicCache, nil
+	return nil
 }
 
 // GetTransferEventMatchedConcrete ...
@@ -111,6 +160,11 @@ func (cd *ContractDecoder) GetTransferEventMatchedConcrete(log *types.Log) (*ric
 
 	// if contretes length larger than 0, decode it
 	if len(contretes) > 0 {
+		// mint and burn events are decoded by DecodeMintOrBurnEvent
+		if contretes[0].ElemType != richtypes.TransferEvent {
+			return nil, nil
+		}
+
 		var erc20 *richtypes.ContractElemConcrete
 		var erc721 *richtypes.ContractElemConcrete
 		var erc777 *richtypes.ContractElemConcrete
@@ -169,12 +223,115 @@ func (cd *ContractDecoder) DecodeEvent(log *types.Log) (eventParmsPtr interface{
 	return nil, nil
 }
 
-// DecodeFunction finds the unique matched event concrete with the log and decodes the log into instance of event params struct
-func (cd *ContractDecoder) DecodeFunction(data []byte) (eventParmsPtr interface{}, err error) {
-	concrete := cd.ElemIdToConcreteDicCache[hexutil.Encode(data[:4])]
+// GetMintOrBurnEventMatchedConcrete returns the concrete of mint or burn event matched with the log
+func (cd *ContractDecoder) GetMintOrBurnEventMatchedConcrete(log *types.Log) *richtypes.ContractElemConcrete {
+	if len(log.Topics) == 0 {
+		return nil
+	}
+
+	contretes := cd.ElemIdToConcreteDicCache[log.Topics[0].String()]
+	for i := range contretes {
+		if contretes[i].ElemType == richtypes.MintEvent || contretes[i].ElemType == richtypes.BurnEvent {
+			return &contretes[i]
+		}
+	}
+	return nil
+}
 
-	if concrete != nil && len(concrete) > 0 {
-		return concrete[0].DecodeFunction(data)
+// DecodeMintOrBurnEvent decodes the log into instance of mint or burn event params struct, it returns nil if log is not one of them
+func (cd *ContractDecoder) DecodeMintOrBurnEvent(log *types.Log) (eventParmsPtr interface{}, err error) {
+	concrete := cd.GetMintOrBurnEventMatchedConcrete(log)
+	if concrete != nil {
+		return concrete.DecodeEvent(log)
 	}
 	return nil, nil
 }
+
+// GetFunctionMatchedConcrete returns the first function concrete matched with the signature of data,
+// use GetFunctionMatchedConcretes for the signature shared by contract types.
+func (cd *ContractDecoder) GetFunctionMatchedConcrete(data []byte) *richtypes.ContractElemConcrete {
+	if len(data) < 4 {
+		return nil
+	}
+
+	concretes := cd.ElemIdToConcreteDicCache[hexutil.Encode(data[:4])]
+	if len(concretes) > 0 {
+		return &concretes[0]
+	}
+	return nil
+}
+
+// GetFunctionMatchedConcretes returns all function concretes matched with the signature of data,
+// there are more than one if the signature is shared by contract types, such as transferFrom of erc20 and erc721.
+func (cd *ContractDecoder) GetFunctionMatchedConcretes(data []byte) []richtypes.ContractElemConcrete {
+	if len(data) < 4 {
+		return nil
+	}
+	return cd.ElemIdToConcreteDicCache[hexutil.Encode(data[:4])]
+}
+
+// DecodeFunction finds the unique matched function concrete with the data and decodes the data into instance of function params struct,
+// it returns error if the signature is shared by contract types which decode data differently, such as transferFrom of erc20 and erc721,
+// use GetFunctionMatchedConcretes and choose the concrete by contract type in that case.
+func (cd *ContractDecoder) DecodeFunction(data []byte) (functionParmsPtr interface{}, err error) {
+	concretes := cd.GetFunctionMatchedConcretes(data)
+	for i := range concretes {
+		params, err := concretes[i].DecodeFunction(data)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 && !reflect.DeepEqual(params, functionParmsPtr) {
+			return nil, fmt.Errorf("could not determine contract type of function %x, which is shared by %v and %v",
+				data[:4], concretes[0].ContractType, concretes[i].ContractType)
+		}
+		functionParmsPtr = params
+	}
+	return functionParmsPtr, nil
+}
+
+// DecodeMethodCall decodes data into method name and arguments according to abiJSON,
+// the ABIs in registry are used when abiJSON is empty or doesn't contain the method.
+func (cd *ContractDecoder) DecodeMethodCall(data []byte, abiJSON string) (*richtypes.MethodCall, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("data %x is too short to contain a method id", data)
+	}
+
+	contracts := make([]*sdk.Contract, 0, len(standardContracts)+1)
+	if abiJSON != "" {
+		contract, err := sdk.NewContract([]byte(abiJSON), nil, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unmarshal json {%+v} to ABI error", abiJSON)
+		}
+		contracts = append(contracts, contract)
+	}
+	contracts = append(contracts, standardContracts...)
+
+	for _, contract := range contracts {
+		method, err := contract.ABI.MethodById(data[:4])
+		if err != nil {
+			continue
+		}
+
+		values, err := method.Inputs.UnpackValues(data[4:])
+		if err != nil {
+			return nil, errors.Wrapf(err, "unpack arguments of method %v error", method.Sig)
+		}
+
+		methodCall := richtypes.MethodCall{
+			Name:      method.RawName,
+			Signature: method.Sig,
+			Payable:   method.IsPayable(),
+			Args:      make([]richtypes.MethodArg, len(values)),
+		}
+		for i, value := range values {
+			methodCall.Args[i] = richtypes.MethodArg{
+				Name:  method.Inputs[i].Name,
+				Type:  method.Inputs[i].Type.String(),
+				Value: value,
+			}
+		}
+		return &methodCall, nil
+	}
+
+	return nil, fmt.Errorf("not found method by id %x", data[:4])
+}
@@ -5,6 +5,8 @@ import (
 	"reflect"
 	"testing"
 
+	sdk "github.com/Conflux-Chain/go-conflux-sdk"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/resource/contract/abi"
 	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
 	"github.com/Conflux-Chain/go-conflux-sdk/types"
 	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
@@ -93,8 +95,131 @@ func TestDecode(t *testing.T) {
 
 }
 
+func TestDecodeFunction(t *testing.T) {
+	from := mustNewCommonAddressByHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302")
+	to := mustNewCommonAddressByHex("0x160ebef20c1f739957bf9eecd040bce699cc42c6")
+	value := big.NewInt(10)
+
+	datas := []struct {
+		expect interface{}
+		data   []byte
+	}{
+		// erc777 operatorSend
+		{
+			expect: &richtypes.ERC777TokenOperatorSendFunctionParams{From: from, To: to, Amount: value, Data: []byte{0x12}, OperatorData: []byte{}},
+			data:   mustGetData(richtypes.ERC777, "operatorSend", from, to, value, []byte{0x12}, []byte{}),
+		},
+		// erc721 safeTransferFrom without data
+		{
+			expect: &richtypes.ERC721TokenTransferFromFunctionParams{From: from, To: to, TokenId: value},
+			data:   mustGetData(richtypes.ERC721, "safeTransferFrom", from, to, value),
+		},
+		// erc721 safeTransferFrom with data
+		{
+			expect: &richtypes.ERC721TokenTransferFromFunctionParams{From: from, To: to, TokenId: value, Data: []byte{0x34}},
+			data:   mustGetData(richtypes.ERC721, "safeTransferFrom0", from, to, value, []byte{0x34}),
+		},
+	}
+
+	contractDecoder, err := NewContractDecoder()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, data := range datas {
+		actual, err := contractDecoder.DecodeFunction(data.data)
+		if err != nil {
+			t.Error(err)
+		}
+
+		if !reflect.DeepEqual(actual, data.expect) {
+			t.Errorf("expect: %#v, acutal: %#v", data.expect, actual)
+		}
+	}
+}
+
+func TestGetFunctionMatchedConcretes(t *testing.T) {
+	contractDecoder, err := NewContractDecoder()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from := mustNewCommonAddressByHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302")
+	to := mustNewCommonAddressByHex("0x160ebef20c1f739957bf9eecd040bce699cc42c6")
+
+	// transferFrom of erc20 and erc721 share the same signature
+	concretes := contractDecoder.GetFunctionMatchedConcretes(mustGetData(richtypes.ERC721, "transferFrom", from, to, big.NewInt(7)))
+	if len(concretes) != 2 || concretes[0].ContractType != richtypes.ERC20 || concretes[1].ContractType != richtypes.ERC721 {
+		t.Fatalf("expect erc20 and erc721 concretes, actual: %+v", concretes)
+	}
+
+	actual, err := concretes[1].DecodeFunction(mustGetData(richtypes.ERC721, "transferFrom", from, to, big.NewInt(7)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := &richtypes.ERC721TokenTransferFromFunctionParams{From: from, To: to, TokenId: big.NewInt(7)}
+	if !reflect.DeepEqual(actual, expect) {
+		t.Errorf("expect: %#v, acutal: %#v", expect, actual)
+	}
+
+	actual, err = concretes[0].DecodeFunction(mustGetData(richtypes.ERC20, "transferFrom", from, to, big.NewInt(7)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := (&richtypes.ERC20TokenTransferFromFunctionParams{From: from, To: to, Value: big.NewInt(7)}); !reflect.DeepEqual(actual, expect) {
+		t.Errorf("expect: %#v, acutal: %#v", expect, actual)
+	}
+
+	// the shared signature could not be decoded without contract type
+	if actual, err = contractDecoder.DecodeFunction(mustGetData(richtypes.ERC721, "transferFrom", from, to, big.NewInt(7))); err == nil {
+		t.Errorf("expect error of ambiguous transferFrom, actual: %#v", actual)
+	}
+}
+
+func TestDecodeCrossSpaceEvent(t *testing.T) {
+	cd, err := NewContractDecoder()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	log := types.Log{
+		Address: cfxaddress.MustNewFromHex("0x0888000000000000000000000000000000000006", cfxaddress.NetowrkTypeMainnetID),
+		Topics: []types.Hash{types.Hash(crossSpaceContract.ABI.Events["Withdraw"].ID.Hex()),
+			"0x160ebef20c1f739957bf9eecd040bce699cc42c6000000000000000000000000",
+			"0x00000000000000000000000019f4bcf113e0b896d9b34294fd3da86b4adf0302"},
+		Data: mustDecodeToHexutilBytes("0x000000000000000000000000000000000000000000000000000000000000000a0000000000000000000000000000000000000000000000000000000000000001"),
+	}
+
+	expect := &richtypes.CrossSpaceWithdrawEventParams{
+		Sender:   mustNewCommonAddressByHex("0x160ebef20c1f739957bf9eecd040bce699cc42c6"),
+		Receiver: mustNewCommonAddressByHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302"),
+		Value:    big.NewInt(10),
+		Nonce:    big.NewInt(1),
+	}
+
+	actual, err := cd.DecodeCrossSpaceEvent(&log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(actual, expect) {
+		t.Fatalf("expect %+v, actual %+v", expect, actual)
+	}
+}
+
+func mustGetData(contractType richtypes.ContractType, method string, args ...interface{}) []byte {
+	contract, err := sdk.NewContract([]byte(abi.GetABI(contractType)), nil, nil)
+	if err != nil {
+		panic(err)
+	}
+	data, err := contract.GetData(method, args...)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
 func mustNewCommonAddressByHex(hexAddress string) common.Address {
-	cfxAddr := cfxaddress.MustNewFromHex("0x00000000000000000000000019f4bcf113e0b896d9b34294fd3da86b4adf0302")
+	cfxAddr := cfxaddress.MustNewFromHex(hexAddress)
 	return cfxAddr.MustGetCommonAddress()
 }
 
@@ -0,0 +1,88 @@
+package decoder
+
+import (
+	"math/big"
+	"strings"
+
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// DecodeDexEvent decodes log of Uniswap V2 style pair into instance of Swap, Mint or Burn event params,
+// it returns nil if log is not one of them.
+func (cd *ContractDecoder) DecodeDexEvent(log *types.Log) (eventParmsPtr interface{}, err error) {
+	if len(log.Topics) == 0 {
+		return nil, nil
+	}
+
+	event, err := dexContract.ABI.EventByID(*log.Topics[0].ToCommonHash())
+	if err != nil {
+		return nil, nil
+	}
+
+	// the indexed arguments are in topics, so the count of topics should be matched
+	indexedCount := len(event.Inputs) - len(event.Inputs.NonIndexed())
+	if len(log.Topics) != indexedCount+1 {
+		return nil, nil
+	}
+
+	switch event.RawName {
+	case "Swap":
+		eventParmsPtr = &richtypes.DexSwapEventParams{}
+	case "Mint":
+		eventParmsPtr = &richtypes.DexMintEventParams{}
+	case "Burn":
+		eventParmsPtr = &richtypes.DexBurnEventParams{}
+	default:
+		return nil, nil
+	}
+
+	if err = dexContract.DecodeEvent(eventParmsPtr, event.RawName, *log); err != nil {
+		return nil, errors.Wrapf(err, "decode event %v of DEX error", event.RawName)
+	}
+	return eventParmsPtr, nil
+}
+
+// DecodeDexRouterFunction decodes data of swap methods of Uniswap V2 style router, it returns nil if data is not one of them.
+func (cd *ContractDecoder) DecodeDexRouterFunction(data []byte) (*richtypes.DexSwapFunctionParams, error) {
+	if len(data) < 4 {
+		return nil, nil
+	}
+
+	method, err := dexContract.ABI.MethodById(data[:4])
+	if err != nil || !strings.HasPrefix(method.RawName, "swap") {
+		return nil, nil
+	}
+
+	result, err := method.Inputs.UnpackValues(data[4:])
+	if err != nil {
+		return nil, errors.Wrapf(err, "unpack arguments of method %v error", method.Sig)
+	}
+
+	args := make(map[string]interface{}, len(result))
+	for i, value := range result {
+		args[method.Inputs[i].Name] = value
+	}
+
+	params := &richtypes.DexSwapFunctionParams{
+		Path: args["path"].([]common.Address),
+		To:   args["to"].(common.Address),
+	}
+
+	// methods swapping exact input are named swapExact..., and the amount in of swapping exact CFX is value of transaction
+	params.ExactIn = strings.HasPrefix(method.RawName, "swapExact")
+	if params.ExactIn {
+		params.AmountOut = args["amountOutMin"].(*big.Int)
+		if amountIn, ok := args["amountIn"]; ok {
+			params.AmountIn = amountIn.(*big.Int)
+		}
+	} else {
+		params.AmountOut = args["amountOut"].(*big.Int)
+		if amountInMax, ok := args["amountInMax"]; ok {
+			params.AmountIn = amountInMax.(*big.Int)
+		}
+	}
+	return params, nil
+}
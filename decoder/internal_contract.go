@@ -0,0 +1,35 @@
+package decoder
+
+import (
+	richconstants "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/constants"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// internalContractTypes maps hex addresses of internal contracts to contract types in registry
+var internalContractTypes = map[common.Address]richtypes.ContractType{
+	richconstants.AdminControlHexAddress:            richtypes.ADMIN,
+	richconstants.SponsorWhitelistControlHexAddress: richtypes.SPONSOR,
+	richconstants.StakingHexAddress:                 richtypes.STAKING,
+	richconstants.CrossSpaceCallHexAddress:          richtypes.CROSSSPACE,
+}
+
+// GetInternalContractType returns contract type of internal contract, it returns false if address is not an internal contract
+func GetInternalContractType(address *types.Address) (richtypes.ContractType, bool) {
+	if address == nil {
+		return "", false
+	}
+	contractType, ok := internalContractTypes[address.MustGetCommonAddress()]
+	return contractType, ok
+}
+
+// IsAdminControl returns true if address is the AdminControl internal contract
+func IsAdminControl(address *types.Address) bool {
+	return address != nil && address.MustGetCommonAddress() == richconstants.AdminControlHexAddress
+}
+
+// IsSponsorWhitelistControl returns true if address is the SponsorWhitelistControl internal contract
+func IsSponsorWhitelistControl(address *types.Address) bool {
+	return address != nil && address.MustGetCommonAddress() == richconstants.SponsorWhitelistControlHexAddress
+}
@@ -0,0 +1,43 @@
+package decoder
+
+import (
+	"math/big"
+
+	richconstants "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/constants"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/pkg/errors"
+)
+
+// IsStaking returns true if address is the Staking internal contract
+func IsStaking(address *types.Address) bool {
+	return address != nil && address.MustGetCommonAddress() == richconstants.StakingHexAddress
+}
+
+// DecodeStakingFunction decodes data of Staking into instance of deposit, withdraw or voteLock params,
+// it returns nil if data is not one of them.
+func (cd *ContractDecoder) DecodeStakingFunction(data []byte) (functionParmsPtr interface{}, err error) {
+	if len(data) < 4 {
+		return nil, nil
+	}
+
+	method, err := stakingContract.ABI.MethodById(data[:4])
+	if err != nil {
+		return nil, nil
+	}
+
+	result, err := method.Inputs.UnpackValues(data[4:])
+	if err != nil {
+		return nil, errors.Wrapf(err, "unpack arguments of method %v error", method.Sig)
+	}
+
+	switch method.RawName {
+	case "deposit":
+		return &richtypes.StakingDepositFunctionParams{Amount: result[0].(*big.Int)}, nil
+	case "withdraw":
+		return &richtypes.StakingWithdrawFunctionParams{Amount: result[0].(*big.Int)}, nil
+	case "voteLock":
+		return &richtypes.StakingVoteLockFunctionParams{Amount: result[0].(*big.Int), UnlockBlockNumber: result[1].(*big.Int)}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,74 @@
+package decoder
+
+import (
+	"math/big"
+
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// DecodeWrappedCFXEvent decodes log of wrapped CFX into instance of deposit or withdrawal event params,
+// it returns nil if log is not Deposit, Withdrawal, Minted or Burned event.
+// The address of log is not checked, so the caller should make sure it is a wrapped CFX contract.
+func (cd *ContractDecoder) DecodeWrappedCFXEvent(log *types.Log) (eventParmsPtr interface{}, err error) {
+	if len(log.Topics) == 0 {
+		return nil, nil
+	}
+
+	event, err := wrappedCFXContract.ABI.EventByID(*log.Topics[0].ToCommonHash())
+	if err != nil {
+		return nil, nil
+	}
+
+	values, err := wrappedCFXContract.ABI.Unpack(event.RawName, log.Data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unpack data of event %v error", event.Sig)
+	}
+
+	// the account is the last indexed argument, and the amount is the first non-indexed argument
+	indexedCount := len(event.Inputs) - len(event.Inputs.NonIndexed())
+	if len(log.Topics) != indexedCount+1 || len(values) == 0 {
+		return nil, nil
+	}
+	account := common.BytesToAddress(log.Topics[indexedCount].ToCommonHash().Bytes())
+	amount, ok := values[0].(*big.Int)
+	if !ok {
+		return nil, nil
+	}
+
+	switch event.RawName {
+	case "Deposit", "Minted":
+		return &richtypes.WrappedCFXDepositEventParams{To: account, Amount: amount}, nil
+	case "Withdrawal", "Burned":
+		return &richtypes.WrappedCFXWithdrawalEventParams{From: account, Amount: amount}, nil
+	}
+	return nil, nil
+}
+
+// DecodeWrappedCFXFunction decodes data of wrapped CFX into instance of deposit or withdraw params,
+// it returns nil if data is not one of them.
+func (cd *ContractDecoder) DecodeWrappedCFXFunction(data []byte) (functionParmsPtr interface{}, err error) {
+	if len(data) < 4 {
+		return nil, nil
+	}
+
+	method, err := wrappedCFXContract.ABI.MethodById(data[:4])
+	if err != nil {
+		return nil, nil
+	}
+
+	result, err := method.Inputs.UnpackValues(data[4:])
+	if err != nil {
+		return nil, errors.Wrapf(err, "unpack arguments of method %v error", method.Sig)
+	}
+
+	switch method.RawName {
+	case "deposit":
+		return &richtypes.WrappedCFXDepositFunctionParams{}, nil
+	case "withdraw":
+		return &richtypes.WrappedCFXWithdrawFunctionParams{Amount: result[0].(*big.Int)}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,145 @@
+package walletsdk
+
+import (
+	"math/big"
+
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/pkg/errors"
+)
+
+// DepositWatcherOption is the option of DepositWatcher
+type DepositWatcherOption struct {
+	// StreamOption is the option of underlying TxDictStream, the Filter of it is replaced by filter of watcher
+	StreamOption TxDictStreamOption
+	// ConfirmedRiskThreshold is the max revert rate of block for regarding movement as confirmed, default is 1e-8
+	ConfirmedRiskThreshold *big.Float
+}
+
+// MovementsHandler handles movements of an epoch emitted by DepositWatcher, the epoch is emitted again
+// if it returns error, so it should be idempotent.
+type MovementsHandler func(epochMovements richtypes.EpochMovements) error
+
+// DepositWatcher watches movements of CFX and tokens touching addresses of filter epoch by epoch,
+// the transactions of other accounts are skipped.
+type DepositWatcher struct {
+	rc      *RichClient
+	filter  *AddressFilter
+	option  DepositWatcherOption
+	stream  *TxDictStream
+	handler MovementsHandler
+}
+
+// NewDepositWatcher creates a DepositWatcher, the addresses and tokens of filter could be changed when watching.
+// The movements of every epoch are delivered to handler at least once, since the checkpoint is saved after it returns.
+func (rc *RichClient) NewDepositWatcher(filter *AddressFilter, handler MovementsHandler, option *DepositWatcherOption) (*DepositWatcher, error) {
+	if filter == nil {
+		return nil, errors.New("filter is nil")
+	}
+	if handler == nil {
+		return nil, errors.New("handler is nil")
+	}
+
+	dw := &DepositWatcher{rc: rc, filter: filter, handler: handler}
+	if option != nil {
+		dw.option = *option
+	}
+	if dw.option.ConfirmedRiskThreshold == nil {
+		dw.option.ConfirmedRiskThreshold = defaultConfirmedRiskThreshold
+	}
+	dw.option.StreamOption.Filter = filter
+
+	stream, err := rc.NewTxDictStream(dw.handleTxDicts, &dw.option.StreamOption)
+	if err != nil {
+		return nil, err
+	}
+	dw.stream = stream
+	return dw, nil
+}
+
+// Start starts watching in a new goroutine
+func (dw *DepositWatcher) Start() error {
+	return dw.stream.Start()
+}
+
+// Stop stops watching
+func (dw *DepositWatcher) Stop() {
+	dw.stream.Stop()
+}
+
+// Sync processes epochs from the next epoch to the followed epoch, the handler is called synchronously
+func (dw *DepositWatcher) Sync() error {
+	return dw.stream.Sync()
+}
+
+// handleTxDicts passes movements of epoch to handler
+func (dw *DepositWatcher) handleTxDicts(epochTxDicts richtypes.EpochTxDicts) error {
+	epochMovements, err := dw.getEpochMovements(&epochTxDicts)
+	if err != nil {
+		dw.stream.handleError(err)
+	}
+	return dw.handler(*epochMovements)
+}
+
+// getEpochMovements filters movements of TxDicts, the status is executed if failed to get latest confirmed epoch
+func (dw *DepositWatcher) getEpochMovements(epochTxDicts *richtypes.EpochTxDicts) (*richtypes.EpochMovements, error) {
+	epochMovements := richtypes.EpochMovements{
+		EpochNumber: epochTxDicts.EpochNumber,
+		PivotHash:   epochTxDicts.PivotHash,
+		Movements:   make([]richtypes.Movement, 0),
+		Reverted:    epochTxDicts.Reverted,
+	}
+	if epochTxDicts.Reverted {
+		return &epochMovements, nil
+	}
+
+	latestConfirmed, err := dw.rc.client.GetEpochNumber(types.EpochLatestConfirmed)
+	if err != nil {
+		err = errors.Wrap(err, "get latest confirmed epoch error")
+	}
+	epochConfirmed := latestConfirmed != nil && latestConfirmed.ToInt().Uint64() >= epochTxDicts.EpochNumber
+
+	for i := range epochTxDicts.TxDicts {
+		movements := FilterMovements(&epochTxDicts.TxDicts[i], dw.filter)
+		for j := range movements {
+			if epochConfirmed {
+				movements[j].Status = richtypes.TxStatusConfirmed
+			} else if movements[j].RevertRate != nil && movements[j].RevertRate.Cmp(dw.option.ConfirmedRiskThreshold) <= 0 {
+				movements[j].Status = richtypes.TxStatusConfirmed
+			}
+		}
+		epochMovements.Movements = append(epochMovements.Movements, movements...)
+	}
+	return &epochMovements, err
+}
+
+// FilterMovements returns movements of TxDict touching addresses and tokens of filter, the status of movements is executed.
+// Nothing is returned for failed TxDict, since nothing is moved except the gas fee.
+func FilterMovements(txDict *richtypes.TxDict, filter *AddressFilter) []richtypes.Movement {
+	movements := make([]richtypes.Movement, 0)
+	if txDict.Failed {
+		return movements
+	}
+
+	appendMovements := func(units []richtypes.TxUnit, direction richtypes.MovementDirection) {
+		for i := range units {
+			unit := units[i]
+			if !filter.containsUnit(&unit) {
+				continue
+			}
+			movements = append(movements, richtypes.Movement{
+				TxUnit:     unit,
+				Direction:  direction,
+				TxHash:     txDict.TxHash,
+				BlockHash:  txDict.BlockHash,
+				TxAt:       txDict.TxAt,
+				RevertRate: txDict.RevertRate,
+				Status:     richtypes.TxStatusExecuted,
+			})
+		}
+	}
+
+	appendMovements(txDict.Outputs, richtypes.MovementIn)
+	appendMovements(txDict.Inputs, richtypes.MovementOut)
+	return movements
+}
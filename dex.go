@@ -0,0 +1,234 @@
+package walletsdk
+
+import (
+	"math/big"
+
+	richconstants "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/constants"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/helper"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/resource/contract/abi"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// dexEvent is the decoded Swap, Mint or Burn event of pair
+type dexEvent struct {
+	pair   types.Address
+	token0 *types.Address
+	token1 *types.Address
+	params interface{}
+}
+
+// SetDexRouters replaces DEX routers whose swap methods are decoded by converter, which are the well-known
+// routers of the network by default.
+func (tc *TxDictConverter) SetDexRouters(routers ...types.Address) {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+
+	tc.dexRouters = make(map[common.Address]struct{}, len(routers))
+	for _, router := range routers {
+		tc.dexRouters[router.MustGetCommonAddress()] = struct{}{}
+	}
+	// pairs are verified by factories of routers
+	tc.pairTokens = make(map[string][2]*types.Address)
+}
+
+func (tc *TxDictConverter) setDefaultDexRouters() {
+	tc.dexRouters = make(map[common.Address]struct{})
+	for _, address := range richconstants.DexRouterHexAddresses[tc.networkID] {
+		tc.dexRouters[address] = struct{}{}
+	}
+}
+
+func (tc *TxDictConverter) isDexRouter(address *types.Address) bool {
+	if address == nil {
+		return false
+	}
+
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+	_, ok := tc.dexRouters[address.MustGetCommonAddress()]
+	return ok
+}
+
+// fillTxDictByDexEvents summarizes swaps and liquidity changes by events of DEX pairs in log order
+func (tc *TxDictConverter) fillTxDictByDexEvents(txDictBase *richtypes.TxDictBase, events []dexEvent) {
+	for _, event := range events {
+		token0, token1 := event.token0, event.token1
+
+		switch params := event.params.(type) {
+		case *richtypes.DexSwapEventParams:
+			tokenIn, amountIn := token0, params.Amount0In
+			if amountIn == nil || amountIn.Sign() == 0 {
+				tokenIn, amountIn = token1, params.Amount1In
+			}
+			tokenOut, amountOut := token1, params.Amount1Out
+			if amountOut == nil || amountOut.Sign() == 0 {
+				tokenOut, amountOut = token0, params.Amount0Out
+			}
+
+			// the output of previous pair is the input of next pair for swapping through multiple pairs
+			if txDictBase.Swap == nil {
+				txDictBase.Swap = &richtypes.SwapSummary{
+					Pairs:    make([]types.Address, 0),
+					TokenIn:  tokenIn,
+					AmountIn: amountIn,
+				}
+			}
+			txDictBase.Swap.Pairs = append(txDictBase.Swap.Pairs, event.pair)
+			txDictBase.Swap.TokenOut = tokenOut
+			txDictBase.Swap.AmountOut = amountOut
+			txDictBase.Swap.To = helper.MustNewCfxAddressPtr(&params.To, tc.networkID)
+		case *richtypes.DexMintEventParams:
+			txDictBase.LiquidityChanges = append(txDictBase.LiquidityChanges, richtypes.LiquidityChange{
+				Pair:    event.pair,
+				Action:  richtypes.LiquidityAdd,
+				Token0:  token0,
+				Amount0: params.Amount0,
+				Token1:  token1,
+				Amount1: params.Amount1,
+			})
+		case *richtypes.DexBurnEventParams:
+			txDictBase.LiquidityChanges = append(txDictBase.LiquidityChanges, richtypes.LiquidityChange{
+				Pair:    event.pair,
+				Action:  richtypes.LiquidityRemove,
+				Token0:  token0,
+				Amount0: params.Amount0,
+				Token1:  token1,
+				Amount1: params.Amount1,
+			})
+		}
+	}
+}
+
+// fillTxDictByDexRouterFunction summarizes swap according to data of unsigned transaction sent to DEX router,
+// it returns false if data is not a swap method.
+func (tc *TxDictConverter) fillTxDictByDexRouterFunction(txDictBase *richtypes.TxDictBase, tx *types.UnsignedTransaction) bool {
+	params, err := tc.decoder.DecodeDexRouterFunction(tx.Data)
+	if err != nil || params == nil || len(params.Path) < 2 {
+		return false
+	}
+
+	// the amount in of swapping CFX is the value of transaction
+	amountIn := params.AmountIn
+	if amountIn == nil {
+		amountIn = new(big.Int)
+		if tx.Value != nil {
+			amountIn = tx.Value.ToInt()
+		}
+	}
+
+	txDictBase.Swap = &richtypes.SwapSummary{
+		TokenIn:   helper.MustNewCfxAddressPtr(&params.Path[0], tc.networkID),
+		AmountIn:  amountIn,
+		TokenOut:  helper.MustNewCfxAddressPtr(&params.Path[len(params.Path)-1], tc.networkID),
+		AmountOut: params.AmountOut,
+		To:        helper.MustNewCfxAddressPtr(&params.To, tc.networkID),
+		IsLimit:   true,
+	}
+	return true
+}
+
+// getPairTokens returns token0 and token1 of DEX pair by calling the pair contract, the pair is verified by
+// getPair of factories of DEX routers, so that events of contracts pretending to be pairs are not summarized.
+// It returns false if the contract is not a pair, which is cached as well unless the calls failed for rpc errors.
+func (tc *TxDictConverter) getPairTokens(pair types.Address) (token0, token1 *types.Address, ok bool) {
+	tc.mutex.Lock()
+	tokens, ok := tc.pairTokens[pair.String()]
+	tc.mutex.Unlock()
+	if ok {
+		return tokens[0], tokens[1], tokens[0] != nil
+	}
+
+	if tc.richClient == nil {
+		return nil, nil, false
+	}
+
+	isPair, _token0, _token1, err := tc.verifyPair(pair)
+	if err != nil {
+		return nil, nil, false
+	}
+	if isPair {
+		token0 = helper.MustNewCfxAddressPtr(&_token0, tc.networkID)
+		token1 = helper.MustNewCfxAddressPtr(&_token1, tc.networkID)
+	}
+
+	tc.mutex.Lock()
+	tc.pairTokens[pair.String()] = [2]*types.Address{token0, token1}
+	tc.mutex.Unlock()
+	return token0, token1, isPair
+}
+
+// verifyPair returns true with tokens of pair if any factory of DEX routers created the pair for the tokens,
+// the contract failed to call token0 or token1 is not a pair.
+func (tc *TxDictConverter) verifyPair(pair types.Address) (isPair bool, token0, token1 common.Address, err error) {
+	contract, err := tc.richClient.GetClient().GetContract([]byte(abi.GetABI(richtypes.DEX)), &pair)
+	if err != nil {
+		return false, token0, token1, errors.Wrap(err, "get pair contract error")
+	}
+
+	if err = contract.Call(nil, &token0, "token0"); err == nil {
+		err = contract.Call(nil, &token1, "token1")
+	}
+	if err != nil {
+		if isCallReverted(err) {
+			return false, token0, token1, nil
+		}
+		return false, token0, token1, errors.Wrapf(err, "get tokens of pair %v error", pair)
+	}
+
+	factories, err := tc.getDexFactories()
+	if err != nil {
+		return false, token0, token1, err
+	}
+	for _, factory := range factories {
+		contract, err := tc.richClient.GetClient().GetContract([]byte(abi.GetABI(richtypes.DEX)), &factory)
+		if err != nil {
+			return false, token0, token1, errors.Wrap(err, "get factory contract error")
+		}
+
+		var created common.Address
+		if err := contract.Call(nil, &created, "getPair", token0, token1); err != nil {
+			return false, token0, token1, errors.Wrapf(err, "get pair of %v and %v from factory %v error", token0, token1, factory)
+		}
+		if created == pair.MustGetCommonAddress() {
+			return true, token0, token1, nil
+		}
+	}
+	return false, token0, token1, nil
+}
+
+// getDexFactories returns factories of DEX routers, which are got by calling the routers and cached.
+func (tc *TxDictConverter) getDexFactories() ([]types.Address, error) {
+	tc.mutex.Lock()
+	routers := make([]common.Address, 0, len(tc.dexRouters))
+	for router := range tc.dexRouters {
+		routers = append(routers, router)
+	}
+	tc.mutex.Unlock()
+
+	factories := make([]types.Address, 0, len(routers))
+	for _, router := range routers {
+		tc.mutex.Lock()
+		factory, ok := tc.dexFactories[router]
+		tc.mutex.Unlock()
+
+		if !ok {
+			routerAddress := helper.MustNewCfxAddressPtr(&router, tc.networkID)
+			contract, err := tc.richClient.GetClient().GetContract([]byte(abi.GetABI(richtypes.DEX)), routerAddress)
+			if err != nil {
+				return nil, errors.Wrap(err, "get router contract error")
+			}
+			if err := contract.Call(nil, &factory, "factory"); err != nil {
+				return nil, errors.Wrapf(err, "get factory of router %v error", routerAddress)
+			}
+
+			tc.mutex.Lock()
+			tc.dexFactories[router] = factory
+			tc.mutex.Unlock()
+		}
+		factories = append(factories, *helper.MustNewCfxAddressPtr(&factory, tc.networkID))
+	}
+	return factories, nil
+}
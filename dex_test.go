@@ -0,0 +1,222 @@
+package walletsdk
+
+import (
+	"math/big"
+	"testing"
+
+	sdk "github.com/Conflux-Chain/go-conflux-sdk"
+	walletinterface "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/interface"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/resource/contract/abi"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestConvertDexSwap(t *testing.T) {
+	converter, err := NewTxDictConverter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+	router := cfxaddress.MustNewFromHex("0x80ae6a88ce3351e9f729e8199f2871ba786ad7c5", cfxaddress.NetowrkTypeMainnetID)
+	pair := cfxaddress.MustNewFromHex("0x8d545118d91c027c805c552f63a5c00a20ae6aca", cfxaddress.NetowrkTypeMainnetID)
+	token0 := cfxaddress.MustNewFromHex("0x8b8689c7f3014a4d86e4d1d0daaf74a47f5e0f27", cfxaddress.NetowrkTypeMainnetID)
+	token1 := cfxaddress.MustNewFromHex("0x8d7df9316faa0586e175b5e6d03c6bda76e3d950", cfxaddress.NetowrkTypeMainnetID)
+	converter.pairTokens[pair.String()] = [2]*types.Address{&token0, &token1}
+
+	dex, err := sdk.NewContract([]byte(abi.GetABI(richtypes.DEX)), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	swapData, err := dex.ABI.Events["Swap"].Inputs.NonIndexed().Pack(big.NewInt(0), big.NewInt(100), big.NewInt(50), big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// swap token1 to token0 by Swap event
+	txDict := new(richtypes.TxDict)
+	receipt := &types.TransactionReceipt{
+		To: &router,
+		Logs: []types.Log{{
+			Address: pair,
+			Topics: []types.Hash{
+				types.Hash(dex.ABI.Events["Swap"].ID.Hex()),
+				types.Hash(common.BytesToHash(router.MustGetCommonAddress().Bytes()).Hex()),
+				types.Hash(common.BytesToHash(user.MustGetCommonAddress().Bytes()).Hex()),
+			},
+			Data: swapData,
+		}},
+	}
+
+	sn := uint64(1)
+	if err := converter.fillTxDictByTxReceipt(txDict, receipt, &sn); err != nil {
+		t.Fatal(err)
+	}
+	swap := txDict.Swap
+	if swap == nil || len(swap.Pairs) != 1 || swap.IsLimit ||
+		swap.TokenIn.String() != token1.String() || swap.AmountIn.Cmp(big.NewInt(100)) != 0 ||
+		swap.TokenOut.String() != token0.String() || swap.AmountOut.Cmp(big.NewInt(50)) != 0 ||
+		swap.To.String() != user.String() {
+		t.Fatalf("expect swap 100 %v to 50 %v, actual: %+v", token1, token0, swap)
+	}
+
+	// swap limit by router method
+	data, err := dex.GetData("swapExactTokensForTokens", big.NewInt(100), big.NewInt(40),
+		[]common.Address{token1.MustGetCommonAddress(), token0.MustGetCommonAddress()}, user.MustGetCommonAddress(), big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := new(types.UnsignedTransaction)
+	tx.From = &user
+	tx.To = &router
+	tx.Value = types.NewBigInt(0)
+	tx.Data = data
+
+	swap = converter.ConvertByUnsignedTransaction(tx).Swap
+	if swap == nil || !swap.IsLimit ||
+		swap.TokenIn.String() != token1.String() || swap.AmountIn.Cmp(big.NewInt(100)) != 0 ||
+		swap.TokenOut.String() != token0.String() || swap.AmountOut.Cmp(big.NewInt(40)) != 0 {
+		t.Fatalf("expect swap limit of 100 %v to at least 40 %v, actual: %+v", token1, token0, swap)
+	}
+
+	// the same method of unknown router is not decoded
+	converter.SetDexRouters()
+	if swap = converter.ConvertByUnsignedTransaction(tx).Swap; swap != nil {
+		t.Fatalf("expect no swap of unknown router, actual: %+v", swap)
+	}
+
+	// the event with Swap signature of contract which is not a pair is ignored
+	delete(converter.pairTokens, pair.String())
+	txDict = new(richtypes.TxDict)
+	if err := converter.fillTxDictByTxReceipt(txDict, receipt, &sn); err != nil {
+		t.Fatal(err)
+	}
+	if txDict.Swap != nil {
+		t.Fatalf("expect no swap of non-pair contract, actual: %+v", txDict.Swap)
+	}
+}
+
+// dexClientMock mocks calls of DEX contracts, calls of contracts without results are reverted
+type dexClientMock struct {
+	sdk.ClientOperator
+	dex     *sdk.Contract
+	results map[string]map[string]common.Address
+	calls   map[string]int
+}
+
+func (c *dexClientMock) GetContract(abiJSON []byte, deployedAt *types.Address) (*sdk.Contract, error) {
+	contract, err := sdk.NewContract(abiJSON, nil, deployedAt)
+	if err != nil {
+		return nil, err
+	}
+	contract.Client = c
+	return contract, nil
+}
+
+func (c *dexClientMock) Call(request types.CallRequest, epoch *types.Epoch) (hexutil.Bytes, error) {
+	c.calls[request.To.String()]++
+	data, err := hexutil.Decode(*request.Data)
+	if err != nil {
+		return nil, err
+	}
+	method, err := c.dex.ABI.MethodById(data[:4])
+	if err != nil {
+		return nil, callRevertError{}
+	}
+	result, ok := c.results[request.To.String()][method.RawName]
+	if !ok {
+		return nil, callRevertError{}
+	}
+	return common.LeftPadBytes(result.Bytes(), 32), nil
+}
+
+// dexRichClientMock mocks methods used by getPairTokens
+type dexRichClientMock struct {
+	walletinterface.RichClientOperator
+	client *dexClientMock
+}
+
+func (rc *dexRichClientMock) GetClient() sdk.ClientOperator {
+	return rc.client
+}
+
+func TestVerifyDexPair(t *testing.T) {
+	converter, err := NewTxDictConverter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+	router := cfxaddress.MustNewFromHex("0x80ae6a88ce3351e9f729e8199f2871ba786ad7c5", cfxaddress.NetowrkTypeMainnetID)
+	factory := cfxaddress.MustNewFromHex("0x8e2f2e68eb75bb8b18caafe9607242d4748f8d98", cfxaddress.NetowrkTypeMainnetID)
+	pair := cfxaddress.MustNewFromHex("0x8d545118d91c027c805c552f63a5c00a20ae6aca", cfxaddress.NetowrkTypeMainnetID)
+	fakePair := cfxaddress.MustNewFromHex("0x8a5c2e6b2f1d6e0ec5b9a2b7d0c3f4e5a6b7c8d9", cfxaddress.NetowrkTypeMainnetID)
+	notPair := cfxaddress.MustNewFromHex("0x8b6d3f7c3a2e7f1fd6cab3c8e1d4a5f6b7c8d9ea", cfxaddress.NetowrkTypeMainnetID)
+	token0 := cfxaddress.MustNewFromHex("0x8b8689c7f3014a4d86e4d1d0daaf74a47f5e0f27", cfxaddress.NetowrkTypeMainnetID)
+	token1 := cfxaddress.MustNewFromHex("0x8d7df9316faa0586e175b5e6d03c6bda76e3d950", cfxaddress.NetowrkTypeMainnetID)
+
+	dex, err := sdk.NewContract([]byte(abi.GetABI(richtypes.DEX)), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens := map[string]common.Address{"token0": token0.MustGetCommonAddress(), "token1": token1.MustGetCommonAddress()}
+	client := &dexClientMock{
+		dex: dex,
+		results: map[string]map[string]common.Address{
+			router.String():  {"factory": factory.MustGetCommonAddress()},
+			factory.String(): {"getPair": pair.MustGetCommonAddress()},
+			// the fake pair claims the tokens of genuine pair
+			pair.String():     tokens,
+			fakePair.String(): tokens,
+		},
+		calls: make(map[string]int),
+	}
+	converter.richClient = &dexRichClientMock{client: client}
+
+	swapData, err := dex.ABI.Events["Swap"].Inputs.NonIndexed().Pack(big.NewInt(0), big.NewInt(100), big.NewInt(50), big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	getSwap := func(emitter types.Address) *richtypes.SwapSummary {
+		txDict := new(richtypes.TxDict)
+		receipt := &types.TransactionReceipt{
+			To: &router,
+			Logs: []types.Log{{
+				Address: emitter,
+				Topics: []types.Hash{
+					types.Hash(dex.ABI.Events["Swap"].ID.Hex()),
+					types.Hash(common.BytesToHash(router.MustGetCommonAddress().Bytes()).Hex()),
+					types.Hash(common.BytesToHash(user.MustGetCommonAddress().Bytes()).Hex()),
+				},
+				Data: swapData,
+			}},
+		}
+		sn := uint64(1)
+		if err := converter.fillTxDictByTxReceipt(txDict, receipt, &sn); err != nil {
+			t.Fatal(err)
+		}
+		return txDict.Swap
+	}
+
+	if swap := getSwap(pair); swap == nil || swap.TokenIn.String() != token1.String() || swap.TokenOut.String() != token0.String() {
+		t.Fatalf("expect swap of pair created by factory, actual: %+v", swap)
+	}
+	if swap := getSwap(fakePair); swap != nil {
+		t.Fatalf("expect no swap of pair not created by factory, actual: %+v", swap)
+	}
+
+	// contracts which are not pairs are not called again
+	for i := 0; i < 2; i++ {
+		if swap := getSwap(notPair); swap != nil {
+			t.Fatalf("expect no swap of non-pair contract, actual: %+v", swap)
+		}
+	}
+	getSwap(fakePair)
+	if client.calls[notPair.String()] != 1 || client.calls[fakePair.String()] != 2 || client.calls[router.String()] != 1 {
+		t.Errorf("expect verification of pairs and factory cached, calls: %v", client.calls)
+	}
+}
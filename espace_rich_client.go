@@ -0,0 +1,538 @@
+package walletsdk
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/decoder"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/constants"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// ESpaceRichClient is the rich client for Conflux eSpace, it talks to eth_ JSON-RPC endpoint
+// and produces the same TxDict and TokenTransferEventList types as RichClient.
+//
+// The addresses of eSpace are hex addresses which could not be represented by types.Address,
+// so they are set to EVM fields of TxUnit and TokenTransferEvent.
+type ESpaceRichClient struct {
+	client     *rpc.Client
+	decoder    *decoder.ContractDecoder
+	tokenCache map[common.Address]*richtypes.Token
+	mutex      sync.Mutex
+}
+
+// evmTransaction is the transaction responsed by eth_getTransactionByHash and eth_getBlockByNumber
+type evmTransaction struct {
+	Hash        common.Hash     `json:"hash"`
+	BlockHash   *common.Hash    `json:"blockHash"`
+	BlockNumber *hexutil.Big    `json:"blockNumber"`
+	From        common.Address  `json:"from"`
+	To          *common.Address `json:"to"`
+	Value       *hexutil.Big    `json:"value"`
+	Gas         *hexutil.Big    `json:"gas"`
+	GasPrice    *hexutil.Big    `json:"gasPrice"`
+	Nonce       *hexutil.Big    `json:"nonce"`
+	Input       hexutil.Bytes   `json:"input"`
+}
+
+// evmReceipt is the receipt responsed by eth_getTransactionReceipt
+type evmReceipt struct {
+	TransactionHash common.Hash     `json:"transactionHash"`
+	BlockHash       common.Hash     `json:"blockHash"`
+	To              *common.Address `json:"to"`
+	ContractAddress *common.Address `json:"contractAddress"`
+	Status          hexutil.Uint64  `json:"status"`
+	Logs            []evmLog        `json:"logs"`
+	// GasUsed is the gas charged, EffectiveGasPrice is missing in receipts of old nodes
+	GasUsed           *hexutil.Big `json:"gasUsed"`
+	EffectiveGasPrice *hexutil.Big `json:"effectiveGasPrice"`
+}
+
+// evmLog is the log responsed by eth_getTransactionReceipt and eth_getLogs
+type evmLog struct {
+	Address         common.Address `json:"address"`
+	Topics          []common.Hash  `json:"topics"`
+	Data            hexutil.Bytes  `json:"data"`
+	BlockHash       common.Hash    `json:"blockHash"`
+	BlockNumber     hexutil.Uint64 `json:"blockNumber"`
+	TransactionHash common.Hash    `json:"transactionHash"`
+	LogIndex        hexutil.Uint   `json:"logIndex"`
+}
+
+// evmBlockHeader is the block responsed by eth_getBlockByHash without full transactions,
+// whose transactions are hashes
+type evmBlockHeader struct {
+	Hash      common.Hash    `json:"hash"`
+	Number    hexutil.Uint64 `json:"number"`
+	Timestamp hexutil.Uint64 `json:"timestamp"`
+}
+
+// evmBlock is the block responsed by eth_getBlockByNumber with full transactions
+type evmBlock struct {
+	evmBlockHeader
+	Transactions []evmTransaction `json:"transactions"`
+}
+
+// NewESpaceRichClient creates ESpaceRichClient connected to eth_ JSON-RPC endpoint of eSpace node
+func NewESpaceRichClient(nodeURL string) (*ESpaceRichClient, error) {
+	client, err := rpc.Dial(nodeURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "dial to espace node %v error", nodeURL)
+	}
+	return NewESpaceRichClientWithRPCClient(client)
+}
+
+// NewESpaceRichClientWithRPCClient creates ESpaceRichClient with rpc client
+func NewESpaceRichClientWithRPCClient(client *rpc.Client) (*ESpaceRichClient, error) {
+	contractDecoder, err := decoder.NewContractDecoder()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ESpaceRichClient{
+		client:     client,
+		decoder:    contractDecoder,
+		tokenCache: make(map[common.Address]*richtypes.Token),
+	}, nil
+}
+
+// GetClient returns rpc client
+func (ec *ESpaceRichClient) GetClient() *rpc.Client {
+	return ec.client
+}
+
+// GetTxDictByTxHash returns all cfx transfers and token transfers of transaction
+func (ec *ESpaceRichClient) GetTxDictByTxHash(hash common.Hash) (*richtypes.TxDict, error) {
+	var tx *evmTransaction
+	if err := ec.client.Call(&tx, "eth_getTransactionByHash", hash); err != nil {
+		return nil, errors.Wrapf(err, "get transaction by hash %v error", hash.Hex())
+	}
+	if tx == nil {
+		return nil, errors.Wrapf(richtypes.ErrTransactionNotFound, "hash %v", hash.Hex())
+	}
+
+	var receipt *evmReceipt
+	if err := ec.client.Call(&receipt, "eth_getTransactionReceipt", hash); err != nil {
+		return nil, errors.Wrapf(err, "get transaction receipt by hash %v error", hash.Hex())
+	}
+
+	var blockTime *hexutil.Uint64
+	if tx.BlockHash != nil {
+		var block *evmBlockHeader
+		if err := ec.client.Call(&block, "eth_getBlockByHash", tx.BlockHash, false); err != nil {
+			return nil, errors.Wrapf(err, "get block by hash %v error", tx.BlockHash.Hex())
+		}
+		if block != nil {
+			blockTime = &block.Timestamp
+		}
+	}
+
+	var revertRate *big.Float
+	if tx.BlockNumber != nil {
+		var err error
+		if revertRate, err = ec.getRevertRate(tx.BlockNumber.ToInt().Uint64()); err != nil {
+			return nil, err
+		}
+	}
+
+	return ec.convertTransaction(tx, receipt, blockTime, revertRate)
+}
+
+// GetTxDictsByBlockNumber returns all cfx transfers and token transfers of the block
+func (ec *ESpaceRichClient) GetTxDictsByBlockNumber(blockNumber uint64) ([]richtypes.TxDict, error) {
+	var block *evmBlock
+	if err := ec.client.Call(&block, "eth_getBlockByNumber", hexutil.Uint64(blockNumber), true); err != nil {
+		return nil, errors.Wrapf(err, "get block by number %v error", blockNumber)
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %v not found", blockNumber)
+	}
+
+	receipts := make([]*evmReceipt, len(block.Transactions))
+	batch := make([]rpc.BatchElem, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		batch[i] = rpc.BatchElem{
+			Method: "eth_getTransactionReceipt",
+			Args:   []interface{}{tx.Hash},
+			Result: &receipts[i],
+		}
+	}
+	if len(batch) > 0 {
+		if err := ec.client.BatchCall(batch); err != nil {
+			return nil, errors.Wrapf(err, "get transaction receipts of block %v error", blockNumber)
+		}
+	}
+
+	revertRate, err := ec.getRevertRate(uint64(block.Number))
+	if err != nil {
+		return nil, err
+	}
+
+	txDicts := make([]richtypes.TxDict, 0, len(block.Transactions))
+	for i := range block.Transactions {
+		if batch[i].Error != nil {
+			return nil, errors.Wrapf(batch[i].Error, "get transaction receipt by hash %v error", block.Transactions[i].Hash.Hex())
+		}
+
+		txDict, err := ec.convertTransaction(&block.Transactions[i], receipts[i], &block.Timestamp, revertRate)
+		if err != nil {
+			return nil, err
+		}
+		txDicts = append(txDicts, *txDict)
+	}
+	return txDicts, nil
+}
+
+// GetAccountTokenTransfers returns token transfers of account between fromBlock and toBlock by eth_getLogs,
+// only transfers of tokenIdentifier are returned if it is not nil.
+func (ec *ESpaceRichClient) GetAccountTokenTransfers(address common.Address, tokenIdentifier *common.Address, fromBlock, toBlock uint64) (*richtypes.TokenTransferEventList, error) {
+	transferTopics := ec.getTransferEventTopics()
+	accountTopic := common.BytesToHash(address.Bytes())
+
+	// the index of from and to topics are 1 and 2 for Transfer event, and 2 and 3 for erc777 Sent event
+	topicsList := [][]interface{}{
+		{transferTopics, accountTopic},
+		{transferTopics, nil, accountTopic},
+		{transferTopics, nil, nil, accountTopic},
+	}
+
+	logs := make([]evmLog, 0)
+	seen := make(map[string]bool)
+	for _, topics := range topicsList {
+		filter := map[string]interface{}{
+			"fromBlock": hexutil.Uint64(fromBlock),
+			"toBlock":   hexutil.Uint64(toBlock),
+			"topics":    topics,
+		}
+		if tokenIdentifier != nil {
+			filter["address"] = tokenIdentifier
+		}
+
+		var result []evmLog
+		if err := ec.client.Call(&result, "eth_getLogs", filter); err != nil {
+			return nil, errors.Wrapf(err, "get logs of %v error", address.Hex())
+		}
+
+		for _, log := range result {
+			key := fmt.Sprintf("%v-%v", log.TransactionHash.Hex(), log.LogIndex)
+			if !seen[key] {
+				seen[key] = true
+				logs = append(logs, log)
+			}
+		}
+	}
+
+	sort.Slice(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber > logs[j].BlockNumber
+		}
+		return logs[i].LogIndex > logs[j].LogIndex
+	})
+
+	blockTimes, err := ec.getBlockTimes(logs)
+	if err != nil {
+		return nil, err
+	}
+
+	tteList := richtypes.TokenTransferEventList{List: make([]richtypes.TokenTransferEvent, 0, len(logs))}
+	for i := range logs {
+		tte, err := ec.convertLogToTokenTransferEvent(&logs[i], blockTimes[logs[i].BlockHash])
+		if err != nil {
+			return nil, err
+		}
+		if tte != nil && (*tte.EVMFrom == address || (tte.EVMTo != nil && *tte.EVMTo == address)) {
+			tteList.List = append(tteList.List, *tte)
+		}
+	}
+	tteList.Total = uint64(len(tteList.List))
+	return &tteList, nil
+}
+
+// getRevertRate returns revert rate of block, eSpace has no confirmation risk of blocks, so it is decided by
+// the finalized block and the safe block, which is in the latest confirmed epoch of core space.
+// The blocks after the safe block are regarded as not confirmed with revert rate 1.
+func (ec *ESpaceRichClient) getRevertRate(blockNumber uint64) (*big.Float, error) {
+	var finalized, safe *evmBlockHeader
+	if err := ec.client.Call(&finalized, "eth_getBlockByNumber", "finalized", false); err != nil {
+		return nil, errors.Wrap(err, "get finalized block error")
+	}
+	if finalized != nil && blockNumber <= uint64(finalized.Number) {
+		return big.NewFloat(0), nil
+	}
+
+	if err := ec.client.Call(&safe, "eth_getBlockByNumber", "safe", false); err != nil {
+		return nil, errors.Wrap(err, "get safe block error")
+	}
+	if safe != nil && blockNumber <= uint64(safe.Number) {
+		return new(big.Float).Set(defaultConfirmedRiskThreshold), nil
+	}
+	return big.NewFloat(1), nil
+}
+
+// convertTransaction converts eSpace transaction and receipt to TxDict, token transfers are ignored if receipt is nil,
+// and the value is zero if the transaction failed. The output of contract creation is the created contract.
+func (ec *ESpaceRichClient) convertTransaction(tx *evmTransaction, receipt *evmReceipt, blockTime *hexutil.Uint64, revertRate *big.Float) (*richtypes.TxDict, error) {
+	txDict := new(richtypes.TxDict)
+	txDict.TxHash = types.Hash(tx.Hash.Hex())
+	txDict.RevertRate = revertRate
+	if tx.BlockHash != nil {
+		blockHash := types.Hash(tx.BlockHash.Hex())
+		txDict.BlockHash = &blockHash
+	}
+	txDict.Extra.Gas = tx.Gas.ToInt()
+	txDict.Extra.GasPrice = tx.GasPrice.ToInt()
+	if blockTime != nil {
+		txDict.TxAt = richtypes.JSONTime(*blockTime)
+	}
+
+	from := tx.From
+	value := tx.Value.ToInt()
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	txDict.Inputs = []richtypes.TxUnit{
+		{
+			Value:        value,
+			EVMAddress:   &from,
+			Sn:           0,
+			TokenCode:    constants.CFXSymbol,
+			TokenDecimal: constants.CFXDecimal,
+		},
+	}
+	txDict.Outputs = []richtypes.TxUnit{
+		{
+			Value:        value,
+			EVMAddress:   tx.To,
+			Sn:           0,
+			TokenCode:    constants.CFXSymbol,
+			TokenDecimal: constants.CFXDecimal,
+		},
+	}
+
+	if receipt == nil {
+		return txDict, nil
+	}
+
+	if tx.To == nil {
+		txDict.Outputs[0].EVMAddress = receipt.ContractAddress
+	}
+	if receipt.GasUsed != nil {
+		gasPrice := receipt.EffectiveGasPrice
+		if gasPrice == nil {
+			gasPrice = tx.GasPrice
+		}
+		if gasPrice != nil {
+			txDict.GasFee = new(big.Int).Mul(receipt.GasUsed.ToInt(), gasPrice.ToInt())
+		}
+	}
+
+	// the value is not transferred and no log is emitted by failed transaction
+	if receipt.Status == 0 {
+		txDict.Failed = true
+		txDict.Inputs[0].Value = big.NewInt(0)
+		txDict.Outputs[0].Value = big.NewInt(0)
+		return txDict, nil
+	}
+
+	sn := uint64(1)
+	for i := range receipt.Logs {
+		log := &receipt.Logs[i]
+		eventParams, concrete, err := ec.decodeTransferLog(log)
+		if err != nil {
+			return nil, errors.Wrapf(err, "decode log %+v of transaction %v error", log, tx.Hash.Hex())
+		}
+		if eventParams == nil {
+			continue
+		}
+
+		amount, tokenID, err := getTransferValue(eventParams)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to get value of log %+v", eventParams)
+		}
+
+		paramsV := reflect.ValueOf(eventParams).Elem()
+		transferFrom := paramsV.FieldByName("From").Interface().(common.Address)
+		transferTo := paramsV.FieldByName("To").Interface().(common.Address)
+		tokenIdentifier := log.Address
+		tokenInfo := ec.getToken(concrete, log.Address)
+
+		input := richtypes.TxUnit{
+			Value:              amount,
+			EVMAddress:         &transferFrom,
+			Sn:                 sn,
+			TokenCode:          tokenInfo.TokenSymbol,
+			TokenDecimal:       tokenInfo.TokenDecimal,
+			TokenId:            tokenID,
+			EVMTokenIdentifier: &tokenIdentifier,
+		}
+		output := input
+		output.EVMAddress = &transferTo
+		setMintOrBurnKind(&input, &output)
+
+		txDict.Inputs = append(txDict.Inputs, input)
+		txDict.Outputs = append(txDict.Outputs, output)
+		sn++
+	}
+	return txDict, nil
+}
+
+// convertLogToTokenTransferEvent converts transfer log to TokenTransferEvent, it returns nil if log is not a transfer
+func (ec *ESpaceRichClient) convertLogToTokenTransferEvent(log *evmLog, blockTime hexutil.Uint64) (*richtypes.TokenTransferEvent, error) {
+	eventParams, concrete, err := ec.decodeTransferLog(log)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decode log %+v error", log)
+	}
+	if eventParams == nil {
+		return nil, nil
+	}
+
+	amount, _, err := getTransferValue(eventParams)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get value of log %+v", eventParams)
+	}
+
+	paramsV := reflect.ValueOf(eventParams).Elem()
+	from := paramsV.FieldByName("From").Interface().(common.Address)
+	to := paramsV.FieldByName("To").Interface().(common.Address)
+	contractAddress := log.Address
+
+	return &richtypes.TokenTransferEvent{
+		Token:               *ec.getToken(concrete, log.Address),
+		TransactionHash:     types.Hash(log.TransactionHash.Hex()),
+		TransactionLogIndex: uint(log.LogIndex),
+		Value:               amount.String(),
+		Timestamp:           richtypes.JSONTime(blockTime),
+		BlockHash:           types.Hash(log.BlockHash.Hex()),
+		EVMContractAddress:  &contractAddress,
+		EVMFrom:             &from,
+		EVMTo:               &to,
+	}, nil
+}
+
+// decodeTransferLog decodes transfer event of erc20, erc721 and erc777, it returns nil if log is not a transfer
+func (ec *ESpaceRichClient) decodeTransferLog(log *evmLog) (interface{}, *richtypes.ContractElemConcrete, error) {
+	// the address of log is not used by decoder, and eSpace address could not be converted to types.Address
+	coreLog := types.Log{
+		Topics: make([]types.Hash, len(log.Topics)),
+		Data:   log.Data,
+	}
+	for i, topic := range log.Topics {
+		coreLog.Topics[i] = types.Hash(topic.Hex())
+	}
+
+	concrete, err := ec.decoder.GetTransferEventMatchedConcrete(&coreLog)
+	if err != nil || concrete == nil {
+		// the log is not a token transfer
+		return nil, nil, nil
+	}
+
+	eventParams, err := concrete.DecodeEvent(&coreLog)
+	if err != nil {
+		return nil, nil, err
+	}
+	return eventParams, concrete, nil
+}
+
+// getToken gets token name, symbol and decimals of contractAddress by eth_call, the empty token is returned if failed
+func (ec *ESpaceRichClient) getToken(concrete *richtypes.ContractElemConcrete, contractAddress common.Address) *richtypes.Token {
+	ec.mutex.Lock()
+	token, ok := ec.tokenCache[contractAddress]
+	ec.mutex.Unlock()
+	if ok {
+		return token
+	}
+
+	token = &richtypes.Token{}
+	contractABI := concrete.Contract.ABI
+
+	call := func(method string) []interface{} {
+		if _, ok := contractABI.Methods[method]; !ok {
+			return nil
+		}
+		data, err := contractABI.Pack(method)
+		if err != nil {
+			return nil
+		}
+		var result hexutil.Bytes
+		if err := ec.client.Call(&result, "eth_call", map[string]interface{}{"to": contractAddress, "data": hexutil.Bytes(data)}, "latest"); err != nil {
+			return nil
+		}
+		values, err := contractABI.Unpack(method, result)
+		if err != nil || len(values) == 0 {
+			return nil
+		}
+		return values
+	}
+
+	// the contract maybe not completely standard, so it is legal without name, symbol or decimals
+	if values := call("name"); values != nil {
+		token.TokenName, _ = values[0].(string)
+	}
+	if values := call("symbol"); values != nil {
+		token.TokenSymbol, _ = values[0].(string)
+	}
+	if values := call("decimals"); values != nil {
+		if decimals, ok := values[0].(uint8); ok {
+			token.TokenDecimal = uint64(decimals)
+		}
+	}
+
+	ec.mutex.Lock()
+	ec.tokenCache[contractAddress] = token
+	ec.mutex.Unlock()
+	return token
+}
+
+// getTransferEventTopics returns event ids of all transfer events in registry
+func (ec *ESpaceRichClient) getTransferEventTopics() []common.Hash {
+	topics := make([]common.Hash, 0)
+	for id, concretes := range ec.decoder.ElemIdToConcreteDicCache {
+		if len(concretes) > 0 && concretes[0].ElemType == richtypes.TransferEvent {
+			topics = append(topics, common.HexToHash(id))
+		}
+	}
+	sort.Slice(topics, func(i, j int) bool { return topics[i].Hex() < topics[j].Hex() })
+	return topics
+}
+
+// getBlockTimes returns timestamps of blocks of logs
+func (ec *ESpaceRichClient) getBlockTimes(logs []evmLog) (map[common.Hash]hexutil.Uint64, error) {
+	blocks := make(map[common.Hash]*evmBlockHeader)
+	batch := make([]rpc.BatchElem, 0)
+	for _, log := range logs {
+		if _, ok := blocks[log.BlockHash]; ok {
+			continue
+		}
+		blocks[log.BlockHash] = nil
+		batch = append(batch, rpc.BatchElem{
+			Method: "eth_getBlockByHash",
+			Args:   []interface{}{log.BlockHash, false},
+			Result: new(*evmBlockHeader),
+		})
+	}
+
+	if len(batch) > 0 {
+		if err := ec.client.BatchCall(batch); err != nil {
+			return nil, errors.Wrap(err, "get blocks by hash error")
+		}
+	}
+
+	blockTimes := make(map[common.Hash]hexutil.Uint64)
+	for _, elem := range batch {
+		if elem.Error != nil {
+			return nil, errors.Wrapf(elem.Error, "get block by hash %v error", elem.Args[0])
+		}
+		if block := *elem.Result.(**evmBlockHeader); block != nil {
+			blockTimes[block.Hash] = block.Timestamp
+		}
+	}
+	return blockTimes, nil
+}
@@ -0,0 +1,232 @@
+package walletsdk
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// newESpaceNodeMock starts a JSON-RPC server responsing results of methods, batch requests are supported,
+// the result keyed by method and the first param, such as `eth_getBlockByNumber "safe"`, is preferred.
+func newESpaceNodeMock(results map[string]string) *httptest.Server {
+	type request struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	type response struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id"`
+		Result  json.RawMessage `json:"result"`
+	}
+	respond := func(req request) response {
+		var result string
+		ok := false
+		if len(req.Params) > 0 {
+			result, ok = results[req.Method+" "+string(req.Params[0])]
+		}
+		if !ok {
+			if result, ok = results[req.Method]; !ok {
+				result = "null"
+			}
+		}
+		return response{JSONRPC: "2.0", ID: req.ID, Result: json.RawMessage(result)}
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+
+		var batch []request
+		if err := json.Unmarshal(body, &batch); err == nil {
+			rsps := make([]response, len(batch))
+			for i := range batch {
+				rsps[i] = respond(batch[i])
+			}
+			json.NewEncoder(w).Encode(rsps)
+			return
+		}
+
+		var req request
+		json.Unmarshal(body, &req)
+		json.NewEncoder(w).Encode(respond(req))
+	}))
+}
+
+func TestESpaceConvertTransaction(t *testing.T) {
+	ec, err := NewESpaceRichClientWithRPCClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := common.HexToAddress("0x8e2f2e68eb75bb8b18caafe9607242d4748f8d98")
+	ec.tokenCache[token] = &richtypes.Token{TokenSymbol: "USDT", TokenDecimal: 18}
+
+	from := common.HexToAddress("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302")
+	to := common.HexToAddress("0x160ebef20c1f739957bf9eecd040bce699cc42c6")
+
+	tx := &evmTransaction{
+		Hash:     common.HexToHash("0x01"),
+		From:     from,
+		To:       &token,
+		Value:    (*hexutil.Big)(big.NewInt(0)),
+		Gas:      (*hexutil.Big)(big.NewInt(21000)),
+		GasPrice: (*hexutil.Big)(big.NewInt(1)),
+	}
+	receipt := &evmReceipt{
+		Status:            1,
+		GasUsed:           (*hexutil.Big)(big.NewInt(21000)),
+		EffectiveGasPrice: (*hexutil.Big)(big.NewInt(2)),
+		Logs: []evmLog{{
+			Address: token,
+			Topics: []common.Hash{
+				crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")),
+				common.BytesToHash(from.Bytes()),
+				common.BytesToHash(to.Bytes()),
+			},
+			Data: common.LeftPadBytes(big.NewInt(100).Bytes(), 32),
+		}},
+	}
+
+	txDict, err := ec.convertTransaction(tx, receipt, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txDict.Outputs) != 2 {
+		t.Fatalf("expect 2 outputs, actual: %+v", txDict.Outputs)
+	}
+
+	if txDict.GasFee == nil || txDict.GasFee.Cmp(big.NewInt(42000)) != 0 {
+		t.Errorf("expect gas fee of gas used and effective gas price, actual: %v", txDict.GasFee)
+	}
+
+	output := txDict.Outputs[1]
+	if output.EVMAddress == nil || *output.EVMAddress != to || output.Value.Cmp(big.NewInt(100)) != 0 {
+		t.Errorf("expect token transfer to %v, actual: %+v", to.Hex(), output)
+	}
+	if output.EVMTokenIdentifier == nil || *output.EVMTokenIdentifier != token || output.TokenCode != "USDT" {
+		t.Errorf("expect token %v, actual: %+v", token.Hex(), output)
+	}
+
+	// eSpace units are matched by hex address
+	filter, err := NewAddressFilter(to.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokenIdentifier := cfxaddress.MustNewFromCommon(token, cfxaddress.NetowrkTypeMainnetID)
+	filter.SetTokens(&tokenIdentifier)
+	if movements := FilterMovements(txDict, filter); len(movements) != 1 || movements[0].Direction != richtypes.MovementIn {
+		t.Errorf("expect 1 incoming movement, actual: %+v", movements)
+	}
+
+	tx.Value = (*hexutil.Big)(big.NewInt(1))
+	receipt.Status = 0
+	txDict, err = ec.convertTransaction(tx, receipt, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !txDict.Failed || len(txDict.Outputs) != 1 || txDict.Outputs[0].Value.Sign() != 0 {
+		t.Errorf("expect failed transaction without movement, actual: %+v", txDict)
+	}
+	if txDict.GasFee == nil || txDict.GasFee.Cmp(big.NewInt(42000)) != 0 {
+		t.Errorf("expect gas fee paid by failed transaction, actual: %v", txDict.GasFee)
+	}
+
+	// the output of contract creation is the created contract, and gas price of transaction is used by old nodes
+	created := common.HexToAddress("0x8d545118d91c027c805c552f63a5c00a20ae6aca")
+	tx.To = nil
+	receipt = &evmReceipt{Status: 1, ContractAddress: &created, GasUsed: (*hexutil.Big)(big.NewInt(21000))}
+	txDict, err = ec.convertTransaction(tx, receipt, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if txDict.Outputs[0].EVMAddress == nil || *txDict.Outputs[0].EVMAddress != created {
+		t.Errorf("expect output of created contract %v, actual: %+v", created.Hex(), txDict.Outputs[0])
+	}
+	if txDict.GasFee == nil || txDict.GasFee.Cmp(big.NewInt(21000)) != 0 {
+		t.Errorf("expect gas fee by gas price of transaction, actual: %v", txDict.GasFee)
+	}
+}
+
+func TestESpaceGetBlockTimes(t *testing.T) {
+	token := "0x8e2f2e68eb75bb8b18caafe9607242d4748f8d98"
+	from := "0x19f4bcf113e0b896d9b34294fd3da86b4adf0302"
+	to := "0x160ebef20c1f739957bf9eecd040bce699cc42c6"
+	txHash := "0x0000000000000000000000000000000000000000000000000000000000000001"
+	blockHash := "0x00000000000000000000000000000000000000000000000000000000000000b1"
+	transferTopic := crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")).Hex()
+
+	tx := `{"hash":"` + txHash + `","blockHash":"` + blockHash + `","blockNumber":"0x10","from":"` + from + `","to":"` + token + `",
+		"value":"0x0","gas":"0x5208","gasPrice":"0x1","nonce":"0x0","input":"0x"}`
+	log := `{"address":"` + token + `","topics":["` + transferTopic + `","` + common.BytesToHash(common.HexToAddress(from).Bytes()).Hex() + `","` +
+		common.BytesToHash(common.HexToAddress(to).Bytes()).Hex() + `"],"data":"0x` + common.Bytes2Hex(common.LeftPadBytes(big.NewInt(100).Bytes(), 32)) + `",
+		"blockHash":"` + blockHash + `","blockNumber":"0x10","transactionHash":"` + txHash + `","logIndex":"0x0"}`
+
+	// the transactions of block are hashes without full transactions
+	server := newESpaceNodeMock(map[string]string{
+		"eth_getTransactionByHash":  tx,
+		"eth_getTransactionReceipt": `{"transactionHash":"` + txHash + `","blockHash":"` + blockHash + `","to":"` + token + `","status":"0x1","logs":[` + log + `]}`,
+		"eth_getBlockByHash":        `{"hash":"` + blockHash + `","number":"0x10","timestamp":"0x5f5e1000","transactions":["` + txHash + `"]}`,
+		"eth_getBlockByNumber":      `{"hash":"` + blockHash + `","number":"0x10","timestamp":"0x5f5e1000","transactions":[` + tx + `]}`,
+		"eth_getLogs":               `[` + log + `]`,
+		"eth_call":                  `"0x"`,
+		// the block is confirmed but not finalized
+		`eth_getBlockByNumber "finalized"`: `{"hash":"` + blockHash + `","number":"0xf","timestamp":"0x5f5e0fff","transactions":[]}`,
+		`eth_getBlockByNumber "safe"`:      `{"hash":"` + blockHash + `","number":"0x10","timestamp":"0x5f5e1000","transactions":[]}`,
+	})
+	defer server.Close()
+
+	client, err := rpc.DialHTTP(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ec, err := NewESpaceRichClientWithRPCClient(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	txDict, err := ec.GetTxDictByTxHash(common.HexToHash(txHash))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if txDict.TxAt != 0x5f5e1000 || len(txDict.Outputs) != 2 {
+		t.Errorf("expect token transfer at block time, actual: %+v", txDict)
+	}
+	if txDict.RevertRate == nil || txDict.RevertRate.Cmp(defaultConfirmedRiskThreshold) != 0 {
+		t.Errorf("expect revert rate of confirmed block, actual: %v", txDict.RevertRate)
+	}
+
+	tteList, err := ec.GetAccountTokenTransfers(common.HexToAddress(to), nil, 0, 0x10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tteList.List) != 1 || tteList.List[0].Timestamp != 0x5f5e1000 {
+		t.Errorf("expect 1 token transfer at block time, actual: %+v", tteList.List)
+	}
+
+	txDicts, err := ec.GetTxDictsByBlockNumber(0x10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txDicts) != 1 || txDicts[0].TxAt != 0x5f5e1000 || txDicts[0].RevertRate == nil {
+		t.Errorf("expect 1 transaction of block, actual: %+v", txDicts)
+	}
+
+	// blocks after the safe block are not confirmed
+	if rate, err := ec.getRevertRate(0x11); err != nil || rate.Cmp(big.NewFloat(1)) != 0 {
+		t.Errorf("expect revert rate 1 of unsafe block, actual: %v, error: %v", rate, err)
+	}
+	if rate, err := ec.getRevertRate(0xf); err != nil || rate.Sign() != 0 {
+		t.Errorf("expect revert rate 0 of finalized block, actual: %v, error: %v", rate, err)
+	}
+}
@@ -32,7 +32,7 @@ var (
 var config exampletypes.Config
 
 func Prepare() *exampletypes.Config {
-	fmt.Println("=======start prepare config===========\n")
+	fmt.Print("=======start prepare config===========\n\n")
 	getConfig()
 	initClient()
 	initRichClient()
@@ -40,7 +40,7 @@ func Prepare() *exampletypes.Config {
 	sendCfx()
 	sendTokens()
 	saveConfig()
-	fmt.Println("=======prepare config done!===========\n")
+	fmt.Print("=======prepare config done!===========\n\n")
 	return &config
 }
 
@@ -36,7 +36,7 @@ func init() {
 }
 
 func main() {
-	fmt.Println("\n=======start rich client example=======\n")
+	fmt.Print("\n=======start rich client example=======\n\n")
 
 	testGetAccountTokenTransfers()
 	testGetAccountTokens()
@@ -45,7 +45,7 @@ func main() {
 	testGetTxDictByTxHash()
 	testGetContractInfo()
 	testGetTransactionsFromPool()
-	fmt.Println("\n=======rich client example done=======\n")
+	fmt.Print("\n=======rich client example done=======\n\n")
 }
 
 func readConfig() {
@@ -7,6 +7,7 @@ import (
 	"github.com/Conflux-Chain/go-conflux-sdk/types"
 	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 // IsFileExists checks if a file exists and is not a directory before we
@@ -52,3 +53,8 @@ func MustNewCfxAddressPtr(commonAddress *common.Address, networkID uint32) *type
 	cfxAddr := cfxaddress.MustNewFromCommon(*commonAddress, networkID)
 	return &cfxAddr
 }
+
+// GetMappedESpaceAddress returns the eSpace mapped address of core space address, which is the last 20 bytes of keccak256 of the hex address
+func GetMappedESpaceAddress(coreAddress common.Address) common.Address {
+	return common.BytesToAddress(crypto.Keccak256(coreAddress.Bytes())[12:])
+}
@@ -0,0 +1,290 @@
+package walletsdk
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/constants"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// ActivitySource provides activity items of account in order, AccountActivityFeed is an ActivitySource
+type ActivitySource interface {
+	Next() bool
+	Item() *richtypes.ActivityItem
+	Err() error
+}
+
+// txDictSource is ActivitySource of TxDicts
+type txDictSource struct {
+	txDicts []richtypes.TxDict
+	current *richtypes.ActivityItem
+}
+
+// NewTxDictSource creates ActivitySource of TxDicts got from chain, such as by GetTxDictsByEpoch
+func NewTxDictSource(txDicts []richtypes.TxDict) ActivitySource {
+	return &txDictSource{txDicts: txDicts}
+}
+
+func (s *txDictSource) Next() bool {
+	if len(s.txDicts) == 0 {
+		s.current = nil
+		return false
+	}
+	s.current = &richtypes.ActivityItem{TxDict: &s.txDicts[0]}
+	s.txDicts = s.txDicts[1:]
+	return true
+}
+
+func (s *txDictSource) Item() *richtypes.ActivityItem {
+	return s.current
+}
+
+func (s *txDictSource) Err() error {
+	return nil
+}
+
+// HistoryExporter writes movements of account in TxDicts to csv
+type HistoryExporter struct {
+	account common.Address
+	option  richtypes.ExportOption
+}
+
+// NewHistoryExporter creates HistoryExporter of account, the default option is used if option is nil
+func NewHistoryExporter(account types.Address, option *richtypes.ExportOption) *HistoryExporter {
+	he := &HistoryExporter{account: account.MustGetCommonAddress()}
+	if option != nil {
+		he.option = *option
+	}
+	if he.option.Format == "" {
+		he.option.Format = richtypes.ExportFormatCSV
+	}
+	if len(he.option.Columns) == 0 {
+		he.option.Columns = richtypes.DefaultExportColumns
+	}
+	if he.option.ConfirmedRiskThreshold == nil {
+		he.option.ConfirmedRiskThreshold = defaultConfirmedRiskThreshold
+	}
+	return he
+}
+
+// Export writes header and rows of all items of source to w, and returns the count of rows written
+func (he *HistoryExporter) Export(w io.Writer, source ActivitySource) (int, error) {
+	header, err := he.header()
+	if err != nil {
+		return 0, err
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return 0, errors.Wrap(err, "write csv header error")
+	}
+
+	count := 0
+	for source.Next() {
+		for _, row := range he.Rows(source.Item().TxDict) {
+			if err := writer.Write(he.record(&row)); err != nil {
+				return count, errors.Wrapf(err, "write row of tx %v error", row.TxHash)
+			}
+			count++
+		}
+	}
+	if err := source.Err(); err != nil {
+		return count, errors.Wrap(err, "iterate activity items error")
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return count, errors.Wrap(err, "flush csv error")
+	}
+	return count, nil
+}
+
+// Rows returns movements of the account in txDict, the self transfer is exported as an out row and an in row,
+// and failed transaction is exported as a row of the gas fee only if it is sent by the account.
+func (he *HistoryExporter) Rows(txDict *richtypes.TxDict) []richtypes.ExportRow {
+	// the gas fee is paid by sender of transaction, which is the first input
+	fee := ""
+	if txDict.GasFee != nil && len(txDict.Inputs) > 0 && isUnitOf(&txDict.Inputs[0], he.account) {
+		fee = richtypes.FormatDripToCFX(txDict.GasFee)
+	}
+
+	base := richtypes.ExportRow{
+		Time:   txDict.TxAt.Time(),
+		Status: he.getStatus(txDict.RevertRate),
+		TxHash: txDict.TxHash,
+	}
+	if txDict.Failed {
+		base.Status = richtypes.ExportStatusFailed
+	}
+
+	rows := make([]richtypes.ExportRow, 0)
+	for i := range txDict.Inputs {
+		if txDict.Failed {
+			break
+		}
+		if i >= len(txDict.Outputs) {
+			break
+		}
+		input, output := &txDict.Inputs[i], &txDict.Outputs[i]
+
+		// zero value movements are only exported for carrying fee
+		if input.Value == nil || (input.Value.Sign() == 0 && !(fee != "" && isUnitOf(input, he.account))) {
+			continue
+		}
+
+		if isUnitOf(input, he.account) {
+			row := base
+			row.Direction = richtypes.HistoryDirectionOut
+			row.Counterparty = output.Address
+			row.EVMCounterparty = output.EVMAddress
+			row.TokenSymbol = getUnitSymbol(input)
+			row.Amount = input.FormattedValue()
+			row.Fee, fee = fee, ""
+			rows = append(rows, row)
+		}
+		if isUnitOf(output, he.account) {
+			row := base
+			row.Direction = richtypes.HistoryDirectionIn
+			row.Counterparty = input.Address
+			row.EVMCounterparty = input.EVMAddress
+			row.TokenSymbol = getUnitSymbol(output)
+			row.Amount = output.FormattedValue()
+			rows = append(rows, row)
+		}
+	}
+
+	// the fee is exported alone if there is no movement of the account
+	if fee != "" {
+		row := base
+		row.Direction = richtypes.HistoryDirectionOut
+		row.TokenSymbol = constants.CFXSymbol
+		row.Amount = "0"
+		row.Fee = fee
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func (he *HistoryExporter) getStatus(revertRate *big.Float) richtypes.ExportStatus {
+	if revertRate == nil {
+		return richtypes.ExportStatusUnknown
+	}
+	if revertRate.Cmp(he.option.ConfirmedRiskThreshold) <= 0 {
+		return richtypes.ExportStatusConfirmed
+	}
+	return richtypes.ExportStatusPending
+}
+
+func (he *HistoryExporter) header() ([]string, error) {
+	switch he.option.Format {
+	case richtypes.ExportFormatCSV:
+		header := make([]string, len(he.option.Columns))
+		for i, column := range he.option.Columns {
+			header[i] = string(column)
+		}
+		return header, nil
+	case richtypes.ExportFormatKoinly:
+		return []string{"Date", "Sent Amount", "Sent Currency", "Received Amount", "Received Currency",
+			"Fee Amount", "Fee Currency", "Net Worth Amount", "Net Worth Currency", "Label", "Description", "TxHash"}, nil
+	case richtypes.ExportFormatCoinTracking:
+		return []string{"Type", "Buy Amount", "Buy Currency", "Sell Amount", "Sell Currency",
+			"Fee", "Fee Currency", "Exchange", "Trade-Group", "Comment", "Date", "Tx-ID"}, nil
+	}
+	return nil, fmt.Errorf("unsupported export format %v", he.option.Format)
+}
+
+func (he *HistoryExporter) record(row *richtypes.ExportRow) []string {
+	counterparty := ""
+	if row.Counterparty != nil {
+		counterparty = row.Counterparty.String()
+	} else if row.EVMCounterparty != nil {
+		counterparty = row.EVMCounterparty.Hex()
+	}
+	feeCurrency := ""
+	if row.Fee != "" {
+		feeCurrency = constants.CFXSymbol
+	}
+	isIn := row.Direction == richtypes.HistoryDirectionIn
+
+	switch he.option.Format {
+	case richtypes.ExportFormatKoinly:
+		record := make([]string, 12)
+		record[0] = row.Time.Format("2006-01-02 15:04:05 UTC")
+		if isIn {
+			record[3], record[4] = row.Amount, row.TokenSymbol
+		} else {
+			record[1], record[2] = row.Amount, row.TokenSymbol
+		}
+		record[5], record[6] = row.Fee, feeCurrency
+		record[10] = counterparty
+		record[11] = string(row.TxHash)
+		return record
+	case richtypes.ExportFormatCoinTracking:
+		record := make([]string, 12)
+		if isIn {
+			record[0] = "Deposit"
+			record[1], record[2] = row.Amount, row.TokenSymbol
+		} else {
+			record[0] = "Withdrawal"
+			record[3], record[4] = row.Amount, row.TokenSymbol
+		}
+		record[5], record[6] = row.Fee, feeCurrency
+		record[7] = "Conflux"
+		record[9] = counterparty
+		record[10] = row.Time.Format("2006-01-02 15:04:05")
+		record[11] = string(row.TxHash)
+		return record
+	}
+
+	record := make([]string, len(he.option.Columns))
+	for i, column := range he.option.Columns {
+		switch column {
+		case richtypes.ExportColumnTime:
+			record[i] = row.Time.Format(time.RFC3339)
+		case richtypes.ExportColumnDirection:
+			record[i] = string(row.Direction)
+		case richtypes.ExportColumnCounterparty:
+			record[i] = counterparty
+		case richtypes.ExportColumnTokenSymbol:
+			record[i] = row.TokenSymbol
+		case richtypes.ExportColumnAmount:
+			record[i] = row.Amount
+		case richtypes.ExportColumnFee:
+			record[i] = row.Fee
+		case richtypes.ExportColumnStatus:
+			record[i] = string(row.Status)
+		case richtypes.ExportColumnTxHash:
+			record[i] = string(row.TxHash)
+		}
+	}
+	return record
+}
+
+// ExportAccountHistory writes the full activity feed of account to w, and returns the count of rows written
+func (rc *RichClient) ExportAccountHistory(w io.Writer, account types.Address, feedOption *richtypes.ActivityFeedOption, exportOption *richtypes.ExportOption) (int, error) {
+	feed, err := rc.NewAccountActivityFeed(account, feedOption)
+	if err != nil {
+		return 0, errors.Wrap(err, "create activity feed error")
+	}
+	return NewHistoryExporter(account, exportOption).Export(w, feed)
+}
+
+// isUnitOf returns true if unit is of account, the eSpace unit is matched by hex address
+func isUnitOf(unit *richtypes.TxUnit, account common.Address) bool {
+	address, ok := unit.GetCommonAddress()
+	return ok && address == account
+}
+
+func getUnitSymbol(unit *richtypes.TxUnit) string {
+	if unit.TokenCode == "" && unit.TokenIdentifier == nil {
+		return constants.CFXSymbol
+	}
+	return unit.TokenCode
+}
@@ -0,0 +1,100 @@
+package walletsdk
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+)
+
+func TestHistoryExporter(t *testing.T) {
+	account := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+	other := cfxaddress.MustNewFromHex("0x1ead8630345121d19ee3604128e5dc54b36e8ea6", cfxaddress.NetowrkTypeMainnetID)
+	token := cfxaddress.MustNewFromHex("0x8b8689c7f3014a4d86e4d1d0daaf74a47f5e0f27", cfxaddress.NetowrkTypeMainnetID)
+
+	oneCFX := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	txDicts := []richtypes.TxDict{
+		{
+			TxDictBase: richtypes.TxDictBase{
+				Inputs:  []richtypes.TxUnit{{Value: oneCFX, Address: &account, TokenCode: "CFX", TokenDecimal: 18}},
+				Outputs: []richtypes.TxUnit{{Value: oneCFX, Address: &other, TokenCode: "CFX", TokenDecimal: 18}},
+			},
+			TxHash:     types.Hash("0x01"),
+			TxAt:       richtypes.JSONTime(1600000000),
+			RevertRate: big.NewFloat(0),
+			GasFee:     big.NewInt(21000000000000),
+		},
+		{
+			TxDictBase: richtypes.TxDictBase{
+				Inputs:  []richtypes.TxUnit{{Value: big.NewInt(1500), Address: &other, TokenCode: "TK", TokenDecimal: 3, TokenIdentifier: &token}},
+				Outputs: []richtypes.TxUnit{{Value: big.NewInt(1500), Address: &account, TokenCode: "TK", TokenDecimal: 3, TokenIdentifier: &token}},
+			},
+			TxHash: types.Hash("0x02"),
+			TxAt:   richtypes.JSONTime(1600000100),
+		},
+	}
+
+	expects := map[richtypes.ExportFormat]string{
+		richtypes.ExportFormatCSV: "time,direction,counterparty,token_symbol,amount,fee,status,tx_hash\n" +
+			"2020-09-13T12:26:40Z,out," + other.String() + ",CFX,1,0.000021,confirmed,0x01\n" +
+			"2020-09-13T12:28:20Z,in," + other.String() + ",TK,1.5,,unknown,0x02\n",
+		richtypes.ExportFormatKoinly: "Date,Sent Amount,Sent Currency,Received Amount,Received Currency,Fee Amount,Fee Currency,Net Worth Amount,Net Worth Currency,Label,Description,TxHash\n" +
+			"2020-09-13 12:26:40 UTC,1,CFX,,,0.000021,CFX,,,," + other.String() + ",0x01\n" +
+			"2020-09-13 12:28:20 UTC,,,1.5,TK,,,,,," + other.String() + ",0x02\n",
+		richtypes.ExportFormatCoinTracking: "Type,Buy Amount,Buy Currency,Sell Amount,Sell Currency,Fee,Fee Currency,Exchange,Trade-Group,Comment,Date,Tx-ID\n" +
+			"Withdrawal,,,1,CFX,0.000021,CFX,Conflux,," + other.String() + ",2020-09-13 12:26:40,0x01\n" +
+			"Deposit,1.5,TK,,,,,Conflux,," + other.String() + ",2020-09-13 12:28:20,0x02\n",
+	}
+
+	for format, expect := range expects {
+		var buf bytes.Buffer
+		count, err := NewHistoryExporter(account, &richtypes.ExportOption{Format: format}).Export(&buf, NewTxDictSource(txDicts))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 2 || buf.String() != expect {
+			t.Errorf("expect %v export:\n%v\nactual %v rows:\n%v", format, expect, count, buf.String())
+		}
+	}
+
+	// columns are configurable
+	var buf bytes.Buffer
+	option := &richtypes.ExportOption{Columns: []richtypes.ExportColumn{richtypes.ExportColumnTxHash, richtypes.ExportColumnAmount}}
+	if _, err := NewHistoryExporter(account, option).Export(&buf, NewTxDictSource(txDicts)); err != nil {
+		t.Fatal(err)
+	}
+	if expect := "tx_hash,amount\n0x01,1\n0x02,1.5\n"; buf.String() != expect {
+		t.Errorf("expect %q, actual %q", expect, buf.String())
+	}
+
+	if _, err := NewHistoryExporter(account, &richtypes.ExportOption{Format: "unknown"}).Export(&buf, NewTxDictSource(nil)); err == nil || !strings.Contains(err.Error(), "unsupported") {
+		t.Errorf("expect unsupported format error, actual: %v", err)
+	}
+}
+
+func TestHistoryExporterFailedTransaction(t *testing.T) {
+	account := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+	other := cfxaddress.MustNewFromHex("0x1ead8630345121d19ee3604128e5dc54b36e8ea6", cfxaddress.NetowrkTypeMainnetID)
+
+	tx := richtypes.Transaction{Hash: types.Hash("0x01"), From: account, To: &other, Value: "1000000000000000000",
+		Status: 1, GasFee: "21000000000000", Timestamp: 1600000000}
+	txDict, err := (&TxDictConverter{}).ConvertByTokenTransferEvent(tx.ToTokenTransferEvent())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !txDict.Failed || txDict.Inputs[0].Value.Sign() != 0 || txDict.Outputs[0].Value.Sign() != 0 {
+		t.Fatalf("expect failed TxDict without value transferred, actual: %+v", txDict)
+	}
+
+	rows := NewHistoryExporter(account, nil).Rows(txDict)
+	if len(rows) != 1 || rows[0].Status != richtypes.ExportStatusFailed || rows[0].Amount != "0" || rows[0].Fee != "0.000021" {
+		t.Errorf("expect a failed row of fee only for sender, actual: %+v", rows)
+	}
+	if rows := NewHistoryExporter(other, nil).Rows(txDict); len(rows) != 0 {
+		t.Errorf("expect no row for receiver, actual: %+v", rows)
+	}
+}
@@ -0,0 +1,86 @@
+package walletsdk
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/decoder"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/helper"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/resource/contract/abi"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// getInternalContractLabel describes the call of AdminControl or SponsorWhitelistControl by data of tx,
+// privileged is true if the call changes admin, destroys contract or changes sponsor whitelist.
+// It returns empty label for other transactions.
+func (tc *TxDictConverter) getInternalContractLabel(tx *types.UnsignedTransaction) (label string, privileged bool) {
+	contractType, ok := decoder.GetInternalContractType(tx.To)
+	if !ok || (contractType != richtypes.ADMIN && contractType != richtypes.SPONSOR) || len(tx.Data) < 4 {
+		return "", false
+	}
+
+	method, err := tc.decoder.DecodeMethodCall(tx.Data, abi.GetABI(contractType))
+	if err != nil {
+		return "", false
+	}
+
+	args := method.Args
+	value := big.NewInt(0)
+	if tx.Value != nil {
+		value = tx.Value.ToInt()
+	}
+
+	switch method.Signature {
+	case "setAdmin(address,address)":
+		return fmt.Sprintf("transfer admin of contract %v to %v", tc.formatArgAddress(args[0].Value, ""), tc.formatArgAddress(args[1].Value, "nobody (admin removed)")), true
+	case "destroy(address)":
+		return fmt.Sprintf("destroy contract %v", tc.formatArgAddress(args[0].Value, "")), true
+	case "setSponsorForGas(address,uint256)":
+		upperBound, _ := args[1].Value.(*big.Int)
+		return fmt.Sprintf("sponsor gas of contract %v with %v CFX, upper bound %v CFX per transaction",
+			tc.formatArgAddress(args[0].Value, ""), richtypes.FormatDripToCFX(value), richtypes.FormatDripToCFX(upperBound)), false
+	case "setSponsorForCollateral(address)":
+		return fmt.Sprintf("sponsor storage collateral of contract %v with %v CFX",
+			tc.formatArgAddress(args[0].Value, ""), richtypes.FormatDripToCFX(value)), false
+	case "addPrivilegeByAdmin(address,address[])":
+		return fmt.Sprintf("add %v to sponsor whitelist of contract %v",
+			tc.formatArgAddresses(args[1].Value), tc.formatArgAddress(args[0].Value, "")), true
+	case "removePrivilegeByAdmin(address,address[])":
+		return fmt.Sprintf("remove %v from sponsor whitelist of contract %v",
+			tc.formatArgAddresses(args[1].Value), tc.formatArgAddress(args[0].Value, "")), true
+	case "addPrivilege(address[])":
+		return fmt.Sprintf("add %v to sponsor whitelist of sender contract", tc.formatArgAddresses(args[0].Value)), true
+	case "removePrivilege(address[])":
+		return fmt.Sprintf("remove %v from sponsor whitelist of sender contract", tc.formatArgAddresses(args[0].Value)), true
+	}
+	return "", false
+}
+
+// formatArgAddress formats address argument to base32 address, the zero address is formatted as zeroName if it is not empty,
+// such as all users in sponsor whitelist.
+func (tc *TxDictConverter) formatArgAddress(arg interface{}, zeroName string) string {
+	address, ok := arg.(common.Address)
+	if !ok {
+		return fmt.Sprintf("%v", arg)
+	}
+	if address == (common.Address{}) && zeroName != "" {
+		return zeroName
+	}
+	return helper.MustNewCfxAddressPtr(&address, tc.networkID).String()
+}
+
+// formatArgAddresses formats addresses argument of sponsor whitelist, in which the zero address means all users
+func (tc *TxDictConverter) formatArgAddresses(arg interface{}) string {
+	addresses, ok := arg.([]common.Address)
+	if !ok {
+		return fmt.Sprintf("%v", arg)
+	}
+
+	formatted := make([]string, len(addresses))
+	for i := range addresses {
+		formatted[i] = tc.formatArgAddress(addresses[i], "all users")
+	}
+	return fmt.Sprintf("%v", formatted)
+}
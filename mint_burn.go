@@ -0,0 +1,113 @@
+package walletsdk
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/helper"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// mintBurnCounter counts mints and burns of a receipt by event source, because erc777 tokens emit both Minted or Burned
+// and erc20 compatible Transfer event for the same mint or burn.
+type mintBurnCounter map[string]*[2]int
+
+// add counts the mint or burn unit pair and returns false if it is counted by the other source already
+func (c mintBurnCounter) add(byTransferEvent bool, input, output *richtypes.TxUnit) bool {
+	account, _ := output.GetCommonAddress()
+	if input.Kind == richtypes.TxUnitBurn {
+		account, _ = input.GetCommonAddress()
+	}
+	key := fmt.Sprintf("%v-%v-%v-%v", input.Kind, input.GetTokenCommonAddress().Hex(), account.Hex(), input.Value)
+
+	if c[key] == nil {
+		c[key] = new([2]int)
+	}
+	counts := c[key]
+
+	source := 0
+	if !byTransferEvent {
+		source = 1
+	}
+	counts[source]++
+	return counts[source] > counts[1-source]
+}
+
+// setMintOrBurnKind marks the unit pair as mint if input is the zero address or burn if output is the zero address,
+// and the zero address party is removed.
+func setMintOrBurnKind(input, output *richtypes.TxUnit) {
+	isZero := func(address *types.Address, evmAddress *common.Address) bool {
+		if address != nil {
+			return address.MustGetCommonAddress() == (common.Address{})
+		}
+		return evmAddress != nil && *evmAddress == (common.Address{})
+	}
+
+	var kind richtypes.TxUnitKind
+	switch {
+	case isZero(input.Address, input.EVMAddress):
+		kind = richtypes.TxUnitMint
+		input.Address, input.EVMAddress = nil, nil
+	case isZero(output.Address, output.EVMAddress):
+		kind = richtypes.TxUnitBurn
+		output.Address, output.EVMAddress = nil, nil
+	default:
+		return
+	}
+	input.Kind, output.Kind = kind, kind
+}
+
+// fillTxDictByMintOrBurnEvent fills tokens created or destroyed by erc777 Minted or Burned event of the token emitting log,
+// it returns false if log is not one of them.
+func (tc *TxDictConverter) fillTxDictByMintOrBurnEvent(txDict *richtypes.TxDict, log *types.Log, counter mintBurnCounter, sn *uint64) (bool, error) {
+	concrete := tc.decoder.GetMintOrBurnEventMatchedConcrete(log)
+	if concrete == nil {
+		return false, nil
+	}
+
+	eventParams, err := concrete.DecodeEvent(log)
+	if err != nil {
+		return false, errors.Wrapf(err, "decode %v event error", concrete.ElemName)
+	}
+
+	tokenIdentifier := log.Address
+	tokenInfo := &richtypes.Token{}
+	if tc.richClient != nil {
+		if _tokenInfo := tc.getTokenByConcrete(concrete, tokenIdentifier); _tokenInfo != nil {
+			tokenInfo = _tokenInfo
+		}
+	}
+
+	input := richtypes.TxUnit{
+		Sn:              *sn,
+		TokenCode:       tokenInfo.TokenSymbol,
+		TokenIdentifier: &tokenIdentifier,
+		TokenDecimal:    tokenInfo.TokenDecimal,
+	}
+	output := input
+
+	paramsV := reflect.ValueOf(eventParams).Elem()
+	input.Value = paramsV.FieldByName("Amount").Interface().(*big.Int)
+	output.Value = input.Value
+	switch concrete.ElemType {
+	case richtypes.MintEvent:
+		to := paramsV.FieldByName("To").Interface().(common.Address)
+		input.Kind, output.Kind = richtypes.TxUnitMint, richtypes.TxUnitMint
+		output.Address = helper.MustNewCfxAddressPtr(&to, tc.networkID)
+	case richtypes.BurnEvent:
+		from := paramsV.FieldByName("From").Interface().(common.Address)
+		input.Kind, output.Kind = richtypes.TxUnitBurn, richtypes.TxUnitBurn
+		input.Address = helper.MustNewCfxAddressPtr(&from, tc.networkID)
+	}
+
+	if counter.add(false, &input, &output) {
+		txDict.Inputs = append(txDict.Inputs, input)
+		txDict.Outputs = append(txDict.Outputs, output)
+		(*sn)++
+	}
+	return true, nil
+}
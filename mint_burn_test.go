@@ -0,0 +1,81 @@
+package walletsdk
+
+import (
+	"math/big"
+	"testing"
+
+	sdk "github.com/Conflux-Chain/go-conflux-sdk"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/resource/contract/abi"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestConvertERC777Mint(t *testing.T) {
+	converter, err := NewTxDictConverter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	erc777, err := sdk.NewContract([]byte(abi.GetABI(richtypes.ERC777)), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	erc20, err := sdk.NewContract([]byte(abi.GetABI(richtypes.ERC20)), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := cfxaddress.MustNewFromHex("0x8e2f2e68eb75bb8b18caafe9607242d4748f8d98", cfxaddress.NetowrkTypeMainnetID)
+	holder := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+	holderTopic := types.Hash(common.BytesToHash(holder.MustGetCommonAddress().Bytes()).Hex())
+	zeroTopic := types.Hash(common.Hash{}.Hex())
+
+	mintedData, err := erc777.ABI.Events["Minted"].Inputs.NonIndexed().Pack(big.NewInt(100), []byte{}, []byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	transferData, err := erc20.ABI.Events["Transfer"].Inputs.NonIndexed().Pack(big.NewInt(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// erc777 token emits both Minted and erc20 compatible Transfer event for the mint called by another contract
+	minter := cfxaddress.MustNewFromHex("0x8a2f2e68eb75bb8b18caafe9607242d4748f8d98", cfxaddress.NetowrkTypeMainnetID)
+	receipt := &types.TransactionReceipt{
+		To: &minter,
+		Logs: []types.Log{
+			{
+				Address: token,
+				Topics:  []types.Hash{types.Hash(erc777.ABI.Events["Minted"].ID.Hex()), holderTopic, holderTopic},
+				Data:    mintedData,
+			},
+			{
+				Address: token,
+				Topics:  []types.Hash{types.Hash(erc20.ABI.Events["Transfer"].ID.Hex()), zeroTopic, holderTopic},
+				Data:    transferData,
+			},
+		},
+	}
+
+	txDict := new(richtypes.TxDict)
+	sn := uint64(1)
+	if err := converter.fillTxDictByTxReceipt(txDict, receipt, &sn); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(txDict.Inputs) != 1 {
+		t.Fatalf("expect 1 mint, actual: %+v", txDict.Inputs)
+	}
+	input, output := txDict.Inputs[0], txDict.Outputs[0]
+	if input.Kind != richtypes.TxUnitMint || input.Address != nil {
+		t.Errorf("expect mint without input address, actual: %+v", input)
+	}
+	if output.Kind != richtypes.TxUnitMint || output.Address.String() != holder.String() || output.Value.Cmp(big.NewInt(100)) != 0 {
+		t.Errorf("expect 100 tokens minted to %v, actual: %+v", holder, output)
+	}
+	if output.TokenIdentifier == nil || output.TokenIdentifier.String() != token.String() {
+		t.Errorf("expect token %v, actual: %+v", token, output)
+	}
+}
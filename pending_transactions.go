@@ -0,0 +1,181 @@
+package walletsdk
+
+import (
+	"encoding/json"
+	"math/big"
+	"sort"
+
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// accountPendingTransactions is the response of cfx_getAccountPendingTransactions
+type accountPendingTransactions struct {
+	PendingCount        hexutil.Uint64      `json:"pendingCount"`
+	PendingTransactions []types.Transaction `json:"pendingTransactions"`
+	// FirstTxStatus is "ready" or {"pending": "futureNonce" | "notEnoughCash"}
+	FirstTxStatus json.RawMessage `json:"firstTxStatus"`
+}
+
+// GetAccountPendingTransactions returns pending transactions of account in pool of conflux node,
+// every transaction is converted to TxDictBase with status ready, futureNonce or notEnoughCash
+// which explains why it is not packed.
+//
+// It works with cfx_getAccountPendingTransactions and falls back to txpool_accountPendingTransactions.
+func (rc *RichClient) GetAccountPendingTransactions(account types.Address) (*richtypes.AccountPendingTxDicts, error) {
+	var rsp accountPendingTransactions
+	if err := rc.client.CallRPC(&rsp, "cfx_getAccountPendingTransactions", account); err != nil {
+		if err2 := rc.client.CallRPC(&rsp, "txpool_accountPendingTransactions", account); err2 != nil {
+			return nil, errors.Wrapf(err, "get pending transactions of %v error", account)
+		}
+	}
+
+	stateNonce, err := rc.client.GetNextNonce(account)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get next nonce of %v error", account)
+	}
+
+	pendingNonce, err := rc.getPendingNonce(account)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := rc.client.GetBalance(account)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get balance of %v error", account)
+	}
+
+	tc, err := NewTxDictConverter(rc)
+	if err != nil {
+		return nil, errors.Wrap(err, "create TxDictConverter error")
+	}
+
+	txs := rsp.PendingTransactions
+	sort.Slice(txs, func(i, j int) bool { return txs[i].Nonce.ToInt().Cmp(txs[j].Nonce.ToInt()) < 0 })
+
+	result := richtypes.AccountPendingTxDicts{
+		Address:      account,
+		StateNonce:   stateNonce.ToInt(),
+		PendingNonce: pendingNonce,
+		PendingCount: uint64(rsp.PendingCount),
+		List:         make([]richtypes.PendingTxDict, 0, len(txs)),
+	}
+
+	unsignedTxs := make([]*types.UnsignedTransaction, len(txs))
+	for i := range txs {
+		if unsignedTxs[i], err = toUnsignedTransaction(&txs[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	// the fee covered by sponsor is not paid by sender
+	statuses, err := getPendingStatuses(unsignedTxs, stateNonce.ToInt(), balance.ToInt(), rc.getSenderMaxCost)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get pending status of transactions of %v error", account)
+	}
+
+	// the status of first transaction given by node is more accurate
+	if len(statuses) > 0 {
+		if firstStatus := parsePendingStatus(rsp.FirstTxStatus); firstStatus != "" {
+			statuses[0] = firstStatus
+		}
+	}
+
+	for i := range txs {
+		result.List = append(result.List, richtypes.PendingTxDict{
+			TxDictBase: *tc.ConvertByUnsignedTransaction(unsignedTxs[i]),
+			TxHash:     txs[i].Hash,
+			Nonce:      txs[i].Nonce.ToInt(),
+			Status:     statuses[i],
+		})
+	}
+
+	return &result, nil
+}
+
+// getPendingStatuses returns pending status of txs sorted by nonce, the cost paid by sender of every transaction is got by getCost.
+// Transactions are packed in nonce order, so a nonce gap blocks all the following ones,
+// and every transaction costs balance left by the previous ones.
+func getPendingStatuses(txs []*types.UnsignedTransaction, stateNonce, balance *big.Int, getCost func(tx *types.UnsignedTransaction) (*big.Int, error)) ([]richtypes.PendingStatus, error) {
+	statuses := make([]richtypes.PendingStatus, len(txs))
+	expectedNonce := new(big.Int).Set(stateNonce)
+	leftBalance := new(big.Int).Set(balance)
+	gapped := false
+	for i, tx := range txs {
+		if gapped || tx.Nonce.ToInt().Cmp(expectedNonce) != 0 {
+			gapped = true
+			statuses[i] = richtypes.PendingFutureNonce
+			continue
+		}
+
+		cost, err := getCost(tx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get cost of transaction with nonce %v error", tx.Nonce)
+		}
+		leftBalance.Sub(leftBalance, cost)
+		statuses[i] = richtypes.PendingReady
+		if leftBalance.Sign() < 0 {
+			statuses[i] = richtypes.PendingNotEnoughCash
+		}
+		expectedNonce.Add(expectedNonce, big.NewInt(1))
+	}
+	return statuses, nil
+}
+
+// parsePendingStatus parses "ready" or {"pending": "futureNonce"} to PendingStatus, it returns empty if unknown
+func parsePendingStatus(raw json.RawMessage) richtypes.PendingStatus {
+	if len(raw) == 0 {
+		return ""
+	}
+
+	var status string
+	if err := json.Unmarshal(raw, &status); err == nil {
+		if status == string(richtypes.PendingReady) {
+			return richtypes.PendingReady
+		}
+		return ""
+	}
+
+	var pending struct {
+		Pending string `json:"pending"`
+	}
+	if err := json.Unmarshal(raw, &pending); err == nil {
+		switch richtypes.PendingStatus(pending.Pending) {
+		case richtypes.PendingFutureNonce:
+			return richtypes.PendingFutureNonce
+		case richtypes.PendingNotEnoughCash:
+			return richtypes.PendingNotEnoughCash
+		}
+	}
+	return ""
+}
+
+// toUnsignedTransaction converts transaction responsed by conflux node to unsigned transaction
+func toUnsignedTransaction(tx *types.Transaction) (*types.UnsignedTransaction, error) {
+	data, err := hexutil.Decode(tx.Data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decode data %v of transaction %v error", tx.Data, tx.Hash)
+	}
+
+	from := tx.From
+	unsignedTx := new(types.UnsignedTransaction)
+	unsignedTx.From = &from
+	unsignedTx.To = tx.To
+	unsignedTx.Nonce = tx.Nonce
+	unsignedTx.Value = tx.Value
+	unsignedTx.Gas = tx.Gas
+	unsignedTx.GasPrice = tx.GasPrice
+	unsignedTx.Data = data
+	if tx.StorageLimit != nil {
+		unsignedTx.StorageLimit = types.NewUint64(tx.StorageLimit.ToInt().Uint64())
+	}
+	if tx.EpochHeight != nil {
+		unsignedTx.EpochHeight = types.NewUint64(tx.EpochHeight.ToInt().Uint64())
+	}
+	if tx.ChainID != nil {
+		unsignedTx.ChainID = types.NewUint(uint(tx.ChainID.ToInt().Uint64()))
+	}
+	return unsignedTx, nil
+}
@@ -0,0 +1,97 @@
+package walletsdk
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+)
+
+func TestParsePendingStatus(t *testing.T) {
+	cases := map[string]richtypes.PendingStatus{
+		``:                             "",
+		`"ready"`:                      richtypes.PendingReady,
+		`"unknown"`:                    "",
+		`{"pending":"futureNonce"}`:    richtypes.PendingFutureNonce,
+		`{"pending":"notEnoughCash"}`:  richtypes.PendingNotEnoughCash,
+		`{"pending":"oldEpochHeight"}`: "",
+		`null`:                         "",
+	}
+	for raw, expect := range cases {
+		if actual := parsePendingStatus(json.RawMessage(raw)); actual != expect {
+			t.Errorf("expect status %q of %v, actual: %q", expect, raw, actual)
+		}
+	}
+}
+
+func TestGetPendingStatuses(t *testing.T) {
+	sponsored := cfxaddress.MustNewFromHex("0x8b8689c7f3014a4d86e4d1d0daaf74a47f5e0f27", cfxaddress.NetowrkTypeMainnetID)
+	other := cfxaddress.MustNewFromHex("0x160ebef20c1f739957bf9eecd040bce699cc42c6", cfxaddress.NetowrkTypeMainnetID)
+
+	newTx := func(nonce uint64, to *types.Address) *types.UnsignedTransaction {
+		tx := new(types.UnsignedTransaction)
+		tx.Nonce = types.NewBigInt(nonce)
+		tx.To = to
+		return tx
+	}
+
+	// every transaction costs 100 drip except the sponsored one
+	getCost := func(tx *types.UnsignedTransaction) (*big.Int, error) {
+		if tx.To.String() == sponsored.String() {
+			return big.NewInt(0), nil
+		}
+		return big.NewInt(100), nil
+	}
+
+	cases := []struct {
+		name    string
+		txs     []*types.UnsignedTransaction
+		balance int64
+		expect  []richtypes.PendingStatus
+	}{
+		{
+			name:    "ready",
+			txs:     []*types.UnsignedTransaction{newTx(5, &other), newTx(6, &other)},
+			balance: 200,
+			expect:  []richtypes.PendingStatus{richtypes.PendingReady, richtypes.PendingReady},
+		},
+		{
+			name:    "nonce gap blocks the following ones",
+			txs:     []*types.UnsignedTransaction{newTx(5, &other), newTx(7, &other), newTx(8, &other)},
+			balance: 300,
+			expect:  []richtypes.PendingStatus{richtypes.PendingReady, richtypes.PendingFutureNonce, richtypes.PendingFutureNonce},
+		},
+		{
+			name:    "first nonce larger than state nonce",
+			txs:     []*types.UnsignedTransaction{newTx(6, &other)},
+			balance: 100,
+			expect:  []richtypes.PendingStatus{richtypes.PendingFutureNonce},
+		},
+		{
+			name:    "balance is spent by previous ones",
+			txs:     []*types.UnsignedTransaction{newTx(5, &other), newTx(6, &other)},
+			balance: 150,
+			expect:  []richtypes.PendingStatus{richtypes.PendingReady, richtypes.PendingNotEnoughCash},
+		},
+		{
+			name:    "fee of sponsored transaction is not paid by sender",
+			txs:     []*types.UnsignedTransaction{newTx(5, &sponsored), newTx(6, &other)},
+			balance: 100,
+			expect:  []richtypes.PendingStatus{richtypes.PendingReady, richtypes.PendingReady},
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := getPendingStatuses(c.txs, big.NewInt(5), big.NewInt(c.balance), getCost)
+		if err != nil {
+			t.Fatalf("%v: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(actual, c.expect) {
+			t.Errorf("%v: expect %v, actual: %v", c.name, c.expect, actual)
+		}
+	}
+}
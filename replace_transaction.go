@@ -0,0 +1,150 @@
+package walletsdk
+
+import (
+	"fmt"
+	"math/big"
+
+	richconstants "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/constants"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// CreateSpeedUpTransaction creates an unsigned transaction for replacing the pending transaction of hash,
+// which has the same nonce, receiver, value and data but a higher gas price.
+//
+// The gasPrice is optional, it should be raised at least MinGasPriceBumpPercent percent than the original one,
+// and it will be the max of the min bumped gas price and gas price of node when it is nil.
+// The error ErrTransactionPacked is returned if the original transaction is already packed.
+func (rc *RichClient) CreateSpeedUpTransaction(hash types.Hash, gasPrice *hexutil.Big) (*types.UnsignedTransaction, error) {
+	pendingTx, err := rc.getPendingTransaction(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := toUnsignedTransaction(pendingTx)
+	if err != nil {
+		return nil, err
+	}
+	tx.EpochHeight = nil
+	tx.GasPrice = nil
+
+	if err := rc.fillReplacement(tx, pendingTx, gasPrice); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// CreateCancelTransaction creates an unsigned transaction for cancelling the pending transaction of hash,
+// which is a zero value transaction sent to sender self with the same nonce and a higher gas price.
+//
+// The gasPrice is optional and same as CreateSpeedUpTransaction.
+// The error ErrTransactionPacked is returned if the original transaction is already packed.
+func (rc *RichClient) CreateCancelTransaction(hash types.Hash, gasPrice *hexutil.Big) (*types.UnsignedTransaction, error) {
+	pendingTx, err := rc.getPendingTransaction(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	from := pendingTx.From
+	tx := new(types.UnsignedTransaction)
+	tx.From = &from
+	tx.To = &from
+	tx.Value = types.NewBigInt(0)
+	tx.Gas = types.NewBigInt(21000)
+	tx.StorageLimit = types.NewUint64(0)
+
+	if err := rc.fillReplacement(tx, pendingTx, gasPrice); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// getPendingTransaction gets transaction by hash from node or transaction pool, and returns error if it is packed
+func (rc *RichClient) getPendingTransaction(hash types.Hash) (*types.Transaction, error) {
+	tx, err := rc.client.GetTransactionByHash(hash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get transaction by hash %v error", hash)
+	}
+
+	if tx == nil {
+		// getTransactionsFromPool only works on local node, so ignore the error
+		poolTxs, _ := rc.GetTransactionsFromPool()
+		if poolTxs != nil {
+			for i := range *poolTxs {
+				if (*poolTxs)[i].Hash == hash {
+					tx = &(*poolTxs)[i]
+					break
+				}
+			}
+		}
+	}
+
+	if tx == nil {
+		return nil, errors.Wrapf(richtypes.ErrTransactionNotFound, "hash %v", hash)
+	}
+
+	if tx.BlockHash != nil {
+		return nil, errors.Wrapf(richtypes.ErrTransactionPacked, "hash %v, block hash %v", hash, tx.BlockHash)
+	}
+
+	// the transaction is executed by another one with the same nonce if nonce of sender is larger than it
+	nonce, err := rc.client.GetNextNonce(tx.From)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get next nonce of %v error", tx.From)
+	}
+	if nonce.ToInt().Cmp(tx.Nonce.ToInt()) > 0 {
+		return nil, errors.Wrapf(richtypes.ErrTransactionPacked, "hash %v, nonce %v is less than next nonce %v", hash, tx.Nonce, nonce)
+	}
+
+	return tx, nil
+}
+
+// fillReplacement fills nonce, chain id, epoch height and bumped gas price of replacement tx by the pending one
+func (rc *RichClient) fillReplacement(tx *types.UnsignedTransaction, pendingTx *types.Transaction, gasPrice *hexutil.Big) error {
+	tx.Nonce = pendingTx.Nonce
+	if pendingTx.ChainID != nil {
+		tx.ChainID = types.NewUint(uint(pendingTx.ChainID.ToInt().Uint64()))
+	}
+
+	minGasPrice := getMinReplacementGasPrice(pendingTx.GasPrice.ToInt())
+	if gasPrice != nil {
+		if gasPrice.ToInt().Cmp(minGasPrice) < 0 {
+			return fmt.Errorf("gas price %v is too low to replace transaction %v, it should be at least %v", gasPrice, pendingTx.Hash, minGasPrice)
+		}
+		tx.GasPrice = gasPrice
+	} else {
+		nodeGasPrice, err := rc.client.GetGasPrice()
+		if err != nil {
+			return errors.Wrap(err, "get gas price error")
+		}
+		if nodeGasPrice.ToInt().Cmp(minGasPrice) > 0 {
+			minGasPrice = nodeGasPrice.ToInt()
+		}
+		tx.GasPrice = types.NewBigIntByRaw(minGasPrice)
+	}
+
+	// the epoch height of pending transaction maybe too old, so refresh it
+	if err := rc.client.ApplyUnsignedTransactionDefault(tx); err != nil {
+		return errors.Wrapf(err, "apply default fields of replacement of %v error", pendingTx.Hash)
+	}
+	return nil
+}
+
+// getMinReplacementGasPrice returns gas price raised MinGasPriceBumpPercent percent, it is at least 1 drip larger than gasPrice,
+// and nil gasPrice is regarded as 0.
+func getMinReplacementGasPrice(gasPrice *big.Int) *big.Int {
+	if gasPrice == nil {
+		gasPrice = new(big.Int)
+	}
+
+	bumped := new(big.Int).Mul(gasPrice, big.NewInt(100+richconstants.MinGasPriceBumpPercent))
+	bumped.Div(bumped, big.NewInt(100))
+
+	atLeast := new(big.Int).Add(gasPrice, big.NewInt(1))
+	if bumped.Cmp(atLeast) < 0 {
+		return atLeast
+	}
+	return bumped
+}
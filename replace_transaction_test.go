@@ -0,0 +1,90 @@
+package walletsdk
+
+import (
+	"math/big"
+	"testing"
+
+	sdk "github.com/Conflux-Chain/go-conflux-sdk"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// replaceClientMock mocks methods used by getPendingTransaction
+type replaceClientMock struct {
+	sdk.ClientOperator
+	tx        *types.Transaction
+	nextNonce uint64
+}
+
+func (c *replaceClientMock) GetTransactionByHash(hash types.Hash) (*types.Transaction, error) {
+	return c.tx, nil
+}
+
+func (c *replaceClientMock) GetNextNonce(address types.Address, epoch ...*types.Epoch) (*hexutil.Big, error) {
+	return types.NewBigInt(c.nextNonce), nil
+}
+
+func TestGetMinReplacementGasPrice(t *testing.T) {
+	cases := []struct {
+		gasPrice *big.Int
+		expect   *big.Int
+	}{
+		{big.NewInt(1000), big.NewInt(1100)},
+		{big.NewInt(1005), big.NewInt(1105)},
+		// the bumped gas price is at least 1 drip larger
+		{big.NewInt(1), big.NewInt(2)},
+		{big.NewInt(9), big.NewInt(10)},
+		{big.NewInt(0), big.NewInt(1)},
+		{nil, big.NewInt(1)},
+	}
+	for _, c := range cases {
+		if actual := getMinReplacementGasPrice(c.gasPrice); actual.Cmp(c.expect) != 0 {
+			t.Errorf("expect min replacement gas price of %v is %v, actual: %v", c.gasPrice, c.expect, actual)
+		}
+	}
+}
+
+func TestCreateReplacementOfPackedTransaction(t *testing.T) {
+	hash := types.Hash("0x69b2a4a4e79b5b5d2d1a1eb4d0d1c7e0a6e8d1e0c8b1d7a5e6f2c3b4a5d6e7f8")
+	blockHash := types.Hash("0x28d5a5b1b8f6c83e274b7ba1f027d16215596f27ea5effb745994401d23f8a18")
+	from := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+
+	client := &replaceClientMock{
+		tx: &types.Transaction{
+			Hash:      hash,
+			From:      from,
+			Nonce:     types.NewBigInt(5),
+			GasPrice:  types.NewBigInt(1000),
+			BlockHash: &blockHash,
+		},
+		nextNonce: 5,
+	}
+	rc := &RichClient{client: client}
+
+	// the transaction is packed
+	if _, err := rc.CreateSpeedUpTransaction(hash, nil); errors.Cause(err) != richtypes.ErrTransactionPacked {
+		t.Errorf("expect ErrTransactionPacked for speed up packed transaction, actual: %v", err)
+	}
+	if _, err := rc.CreateCancelTransaction(hash, nil); errors.Cause(err) != richtypes.ErrTransactionPacked {
+		t.Errorf("expect ErrTransactionPacked for cancel packed transaction, actual: %v", err)
+	}
+
+	// the nonce is already used by another transaction
+	client.tx.BlockHash = nil
+	client.nextNonce = 6
+	if _, err := rc.CreateSpeedUpTransaction(hash, nil); errors.Cause(err) != richtypes.ErrTransactionPacked {
+		t.Errorf("expect ErrTransactionPacked for speed up transaction of used nonce, actual: %v", err)
+	}
+	if _, err := rc.CreateCancelTransaction(hash, nil); errors.Cause(err) != richtypes.ErrTransactionPacked {
+		t.Errorf("expect ErrTransactionPacked for cancel transaction of used nonce, actual: %v", err)
+	}
+
+	// the gas price is too low to replace
+	client.nextNonce = 5
+	if _, err := rc.CreateSpeedUpTransaction(hash, types.NewBigInt(1099)); err == nil {
+		t.Error("expect error for gas price lower than the min replacement gas price")
+	}
+}
@@ -9,6 +9,12 @@ func init() {
 	ABIJsonDic[richtypes.ERC20] = erc20
 	ABIJsonDic[richtypes.ERC777] = erc777
 	ABIJsonDic[richtypes.ERC721] = erc721
+	ABIJsonDic[richtypes.CROSSSPACE] = crossSpaceCall
+	ABIJsonDic[richtypes.STAKING] = staking
+	ABIJsonDic[richtypes.SPONSOR] = sponsorWhitelistControl
+	ABIJsonDic[richtypes.ADMIN] = adminControl
+	ABIJsonDic[richtypes.WCFX] = wrappedCFX
+	ABIJsonDic[richtypes.DEX] = dex
 }
 
 // GetABI ...
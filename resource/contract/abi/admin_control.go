@@ -0,0 +1,35 @@
+package abi
+
+// adminControl is the ABI of AdminControl internal contract, which transfers admin of contracts and destroys contracts
+var adminControl string = `[
+    {
+        "inputs": [
+            {"name": "contractAddr", "type": "address"},
+            {"name": "newAdmin", "type": "address"}
+        ],
+        "name": "setAdmin",
+        "outputs": [],
+        "stateMutability": "nonpayable",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "contractAddr", "type": "address"}
+        ],
+        "name": "destroy",
+        "outputs": [],
+        "stateMutability": "nonpayable",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "contractAddr", "type": "address"}
+        ],
+        "name": "getAdmin",
+        "outputs": [
+            {"name": "", "type": "address"}
+        ],
+        "stateMutability": "view",
+        "type": "function"
+    }
+]`
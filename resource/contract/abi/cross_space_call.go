@@ -0,0 +1,144 @@
+package abi
+
+// crossSpaceCall is the ABI of CrossSpaceCall internal contract, which moves CFX and calls contracts between Core Space and eSpace
+var crossSpaceCall string = `[
+    {
+        "anonymous": false,
+        "inputs": [
+            {"indexed": true, "name": "sender", "type": "bytes20"},
+            {"indexed": true, "name": "receiver", "type": "bytes20"},
+            {"indexed": false, "name": "value", "type": "uint256"},
+            {"indexed": false, "name": "nonce", "type": "uint256"},
+            {"indexed": false, "name": "data", "type": "bytes"}
+        ],
+        "name": "Call",
+        "type": "event"
+    },
+    {
+        "anonymous": false,
+        "inputs": [
+            {"indexed": true, "name": "sender", "type": "bytes20"},
+            {"indexed": true, "name": "contract_address", "type": "bytes20"},
+            {"indexed": false, "name": "value", "type": "uint256"},
+            {"indexed": false, "name": "nonce", "type": "uint256"},
+            {"indexed": false, "name": "init", "type": "bytes"}
+        ],
+        "name": "Create",
+        "type": "event"
+    },
+    {
+        "anonymous": false,
+        "inputs": [
+            {"indexed": true, "name": "sender", "type": "bytes20"},
+            {"indexed": true, "name": "receiver", "type": "address"},
+            {"indexed": false, "name": "value", "type": "uint256"},
+            {"indexed": false, "name": "nonce", "type": "uint256"}
+        ],
+        "name": "Withdraw",
+        "type": "event"
+    },
+    {
+        "anonymous": false,
+        "inputs": [
+            {"indexed": false, "name": "success", "type": "bool"}
+        ],
+        "name": "Outcome",
+        "type": "event"
+    },
+    {
+        "inputs": [
+            {"name": "init", "type": "bytes"}
+        ],
+        "name": "createEVM",
+        "outputs": [
+            {"name": "", "type": "bytes20"}
+        ],
+        "stateMutability": "payable",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "init", "type": "bytes"},
+            {"name": "salt", "type": "bytes32"}
+        ],
+        "name": "create2EVM",
+        "outputs": [
+            {"name": "", "type": "bytes20"}
+        ],
+        "stateMutability": "payable",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "to", "type": "bytes20"}
+        ],
+        "name": "transferEVM",
+        "outputs": [
+            {"name": "output", "type": "bytes"}
+        ],
+        "stateMutability": "payable",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "to", "type": "bytes20"},
+            {"name": "data", "type": "bytes"}
+        ],
+        "name": "callEVM",
+        "outputs": [
+            {"name": "output", "type": "bytes"}
+        ],
+        "stateMutability": "payable",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "to", "type": "bytes20"},
+            {"name": "data", "type": "bytes"}
+        ],
+        "name": "staticCallEVM",
+        "outputs": [
+            {"name": "output", "type": "bytes"}
+        ],
+        "stateMutability": "view",
+        "type": "function"
+    },
+    {
+        "inputs": [],
+        "name": "deployEip1820",
+        "outputs": [],
+        "stateMutability": "nonpayable",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "value", "type": "uint256"}
+        ],
+        "name": "withdrawFromMapped",
+        "outputs": [],
+        "stateMutability": "nonpayable",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "addr", "type": "address"}
+        ],
+        "name": "mappedBalance",
+        "outputs": [
+            {"name": "", "type": "uint256"}
+        ],
+        "stateMutability": "view",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "addr", "type": "address"}
+        ],
+        "name": "mappedNonce",
+        "outputs": [
+            {"name": "", "type": "uint256"}
+        ],
+        "stateMutability": "view",
+        "type": "function"
+    }
+]`
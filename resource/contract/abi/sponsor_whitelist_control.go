@@ -0,0 +1,141 @@
+package abi
+
+// sponsorWhitelistControl is the ABI of SponsorWhitelistControl internal contract, which manages sponsors of gas and storage collateral
+// and the whitelist of users sponsored by contracts
+var sponsorWhitelistControl string = `[
+    {
+        "inputs": [
+            {"name": "contractAddr", "type": "address"}
+        ],
+        "name": "getSponsorForGas",
+        "outputs": [
+            {"name": "", "type": "address"}
+        ],
+        "stateMutability": "view",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "contractAddr", "type": "address"}
+        ],
+        "name": "getSponsoredBalanceForGas",
+        "outputs": [
+            {"name": "", "type": "uint256"}
+        ],
+        "stateMutability": "view",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "contractAddr", "type": "address"}
+        ],
+        "name": "getSponsoredGasFeeUpperBound",
+        "outputs": [
+            {"name": "", "type": "uint256"}
+        ],
+        "stateMutability": "view",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "contractAddr", "type": "address"}
+        ],
+        "name": "getSponsorForCollateral",
+        "outputs": [
+            {"name": "", "type": "address"}
+        ],
+        "stateMutability": "view",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "contractAddr", "type": "address"}
+        ],
+        "name": "getSponsoredBalanceForCollateral",
+        "outputs": [
+            {"name": "", "type": "uint256"}
+        ],
+        "stateMutability": "view",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "contractAddr", "type": "address"},
+            {"name": "user", "type": "address"}
+        ],
+        "name": "isWhitelisted",
+        "outputs": [
+            {"name": "", "type": "bool"}
+        ],
+        "stateMutability": "view",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "contractAddr", "type": "address"}
+        ],
+        "name": "isAllWhitelisted",
+        "outputs": [
+            {"name": "", "type": "bool"}
+        ],
+        "stateMutability": "view",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "contractAddr", "type": "address"},
+            {"name": "addresses", "type": "address[]"}
+        ],
+        "name": "addPrivilegeByAdmin",
+        "outputs": [],
+        "stateMutability": "nonpayable",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "contractAddr", "type": "address"},
+            {"name": "addresses", "type": "address[]"}
+        ],
+        "name": "removePrivilegeByAdmin",
+        "outputs": [],
+        "stateMutability": "nonpayable",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "contractAddr", "type": "address"},
+            {"name": "upperBound", "type": "uint256"}
+        ],
+        "name": "setSponsorForGas",
+        "outputs": [],
+        "stateMutability": "payable",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "contractAddr", "type": "address"}
+        ],
+        "name": "setSponsorForCollateral",
+        "outputs": [],
+        "stateMutability": "payable",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "addresses", "type": "address[]"}
+        ],
+        "name": "addPrivilege",
+        "outputs": [],
+        "stateMutability": "nonpayable",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "addresses", "type": "address[]"}
+        ],
+        "name": "removePrivilege",
+        "outputs": [],
+        "stateMutability": "nonpayable",
+        "type": "function"
+    }
+]`
@@ -0,0 +1,68 @@
+package abi
+
+// staking is the ABI of Staking internal contract, which deposits CFX to staking balance and locks it for voting
+var staking string = `[
+    {
+        "inputs": [
+            {"name": "amount", "type": "uint256"}
+        ],
+        "name": "deposit",
+        "outputs": [],
+        "stateMutability": "nonpayable",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "amount", "type": "uint256"}
+        ],
+        "name": "withdraw",
+        "outputs": [],
+        "stateMutability": "nonpayable",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "amount", "type": "uint256"},
+            {"name": "unlockBlockNumber", "type": "uint256"}
+        ],
+        "name": "voteLock",
+        "outputs": [],
+        "stateMutability": "nonpayable",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "user", "type": "address"}
+        ],
+        "name": "getStakingBalance",
+        "outputs": [
+            {"name": "", "type": "uint256"}
+        ],
+        "stateMutability": "view",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "user", "type": "address"},
+            {"name": "blockNumber", "type": "uint256"}
+        ],
+        "name": "getLockedStakingBalance",
+        "outputs": [
+            {"name": "", "type": "uint256"}
+        ],
+        "stateMutability": "view",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "user", "type": "address"},
+            {"name": "blockNumber", "type": "uint256"}
+        ],
+        "name": "getVotePower",
+        "outputs": [
+            {"name": "", "type": "uint256"}
+        ],
+        "stateMutability": "view",
+        "type": "function"
+    }
+]`
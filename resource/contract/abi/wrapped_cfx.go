@@ -0,0 +1,64 @@
+package abi
+
+// wrappedCFX is the ABI of wrapped CFX contracts, the Deposit and Withdrawal events are emitted by WETH9 style contracts,
+// and Minted and Burned events are emitted by ERC777 style contracts such as WCFX.
+var wrappedCFX string = `[
+    {
+        "anonymous": false,
+        "inputs": [
+            {"indexed": true, "name": "dst", "type": "address"},
+            {"indexed": false, "name": "wad", "type": "uint256"}
+        ],
+        "name": "Deposit",
+        "type": "event"
+    },
+    {
+        "anonymous": false,
+        "inputs": [
+            {"indexed": true, "name": "src", "type": "address"},
+            {"indexed": false, "name": "wad", "type": "uint256"}
+        ],
+        "name": "Withdrawal",
+        "type": "event"
+    },
+    {
+        "anonymous": false,
+        "inputs": [
+            {"indexed": true, "name": "operator", "type": "address"},
+            {"indexed": true, "name": "to", "type": "address"},
+            {"indexed": false, "name": "amount", "type": "uint256"},
+            {"indexed": false, "name": "data", "type": "bytes"},
+            {"indexed": false, "name": "operatorData", "type": "bytes"}
+        ],
+        "name": "Minted",
+        "type": "event"
+    },
+    {
+        "anonymous": false,
+        "inputs": [
+            {"indexed": true, "name": "operator", "type": "address"},
+            {"indexed": true, "name": "from", "type": "address"},
+            {"indexed": false, "name": "amount", "type": "uint256"},
+            {"indexed": false, "name": "data", "type": "bytes"},
+            {"indexed": false, "name": "operatorData", "type": "bytes"}
+        ],
+        "name": "Burned",
+        "type": "event"
+    },
+    {
+        "inputs": [],
+        "name": "deposit",
+        "outputs": [],
+        "stateMutability": "payable",
+        "type": "function"
+    },
+    {
+        "inputs": [
+            {"name": "wad", "type": "uint256"}
+        ],
+        "name": "withdraw",
+        "outputs": [],
+        "stateMutability": "nonpayable",
+        "type": "function"
+    }
+]`
@@ -10,4 +10,5 @@ var erc20 []richtypes.ContractElem = []richtypes.ContractElem{
 	{ElemName: "symbol", ElemType: richtypes.SymbolFunction},
 	{ElemName: "decimals", ElemType: richtypes.DecimalsFunction},
 	{ElemName: "transfer", ElemType: richtypes.TransferFunction},
+	{ElemName: "transferFrom", ElemType: richtypes.TransferFromFunction},
 }
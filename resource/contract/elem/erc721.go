@@ -8,5 +8,6 @@ var erc721 []richtypes.ContractElem = []richtypes.ContractElem{
 	{ElemName: "Transfer", ElemType: richtypes.TransferEvent},
 	{ElemName: "name", ElemType: richtypes.NameFunction},
 	{ElemName: "symbol", ElemType: richtypes.SymbolFunction},
-	{ElemName: "safeTransferFrom", ElemType: richtypes.TransferFunction},
+	{ElemName: "transferFrom", ElemType: richtypes.TransferFromFunction},
+	{ElemName: "safeTransferFrom", ElemType: richtypes.TransferFromFunction},
 }
@@ -10,4 +10,7 @@ var erc777 []richtypes.ContractElem = []richtypes.ContractElem{
 	{ElemName: "symbol", ElemType: richtypes.SymbolFunction},
 	{ElemName: "decimals", ElemType: richtypes.DecimalsFunction},
 	{ElemName: "send", ElemType: richtypes.TransferFunction},
+	{ElemName: "operatorSend", ElemType: richtypes.TransferFromFunction},
+	{ElemName: "Minted", ElemType: richtypes.MintEvent},
+	{ElemName: "Burned", ElemType: richtypes.BurnEvent},
 }
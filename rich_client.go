@@ -29,6 +29,9 @@ type RichClient struct {
 	cfxScanBackend  *scanServer
 	contractManager *scanServer
 	client          sdk.ClientOperator
+	tokenReputation *TokenReputationChecker
+	tokenLists      *TokenListRegistry
+	tokenIcons      *TokenIconCache
 }
 
 // ServerConfig represents cfx-scan-backend and contract-manager configurations, because centralized servers maybe changed.
@@ -52,7 +55,6 @@ type blockAndRevertrate struct {
 type contactInfoKey struct {
 	contractAddress string
 	needABI         bool
-	needIcon        bool
 }
 
 // default value of server config
@@ -118,9 +120,9 @@ func NewRichClient(client sdk.ClientOperator, configOption *ServerConfig) *RichC
 	}
 
 	richClient := RichClient{
-		cfxScanBackend,
-		contractManager,
-		client,
+		cfxScanBackend:  cfxScanBackend,
+		contractManager: contractManager,
+		client:          client,
 	}
 
 	return &richClient
@@ -141,19 +143,38 @@ func (rc *RichClient) setHTTPRequester(requester sdk.HTTPRequester) {
 // the tokenIdentifier represnets the token contract address and it is optional,
 // when tokenIdentifier is specicied it returns token transfer events related the address,
 // otherwise returns transactions about main coin.
+//
+// The pageNumber starts from 1, use NewAccountHistoryIterator for iterating the full history without duplicates or gaps.
 func (rc *RichClient) GetAccountTokenTransfers(address types.Address, tokenIdentifier *types.Address, pageNumber, pageSize uint) (*richtypes.TokenTransferEventList, error) {
+	if pageNumber == 0 {
+		return nil, errors.New("pageNumber should start from 1")
+	}
+
 	params := make(map[string]interface{})
-	params["accountAddress"] = address
 	params["skip"] = (pageNumber - 1) * pageSize
 	params["limit"] = pageSize
-	params["transferType"] = "ERC20"
+	list, err := rc.getAccountTokenTransfers(address, tokenIdentifier, richtypes.GetTransferType(tokenIdentifier), params)
+	if err != nil {
+		return nil, err
+	}
+	rc.hideFlaggedTokenTransferEvents(list)
+	return list, nil
+}
+
+// getAccountTokenTransfers returns token transfer events by params of scan server, such as skip and limit,
+// it returns transactions of main coin if transferType is CFX, otherwise returns token transfer events of transferType
+// and transfer events of all tokens are returned if tokenIdentifier is nil.
+func (rc *RichClient) getAccountTokenTransfers(address types.Address, tokenIdentifier *types.Address, transferType richtypes.TransferType, params map[string]interface{}) (*richtypes.TokenTransferEventList, error) {
+	params["accountAddress"] = address
 
 	var tteList *richtypes.TokenTransferEventList
 	blockhashes := []types.Hash{}
-	// when tokenIdentifier is not nil return transfer events of the token
-	if tokenIdentifier != nil {
+	if transferType != richtypes.TransferTypeCFX {
 		var tts richtypes.TokenTransferEventList
-		params["address"] = *tokenIdentifier
+		params["transferType"] = transferType
+		if tokenIdentifier != nil {
+			params["address"] = *tokenIdentifier
+		}
 		err := rc.cfxScanBackend.Get(tokenTransferListPath, params, &tts)
 		if err != nil {
 			return nil, errors.Wrapf(err, "get result of CfxScanBackend server and path {%+v}, params: {%+v} error", tokenTransferListPath, params)
@@ -183,6 +204,9 @@ func (rc *RichClient) GetAccountTokenTransfers(address types.Address, tokenIdent
 			if tx != nil && tx.BlockHash != nil {
 				tteList.List[i].BlockHash = *txhashToTxMap[hash].BlockHash
 			}
+			if tx != nil && tx.TransactionIndex != nil && tteList.List[i].TransactionIndex == 0 {
+				tteList.List[i].TransactionIndex = uint64(*tx.TransactionIndex)
+			}
 		}
 
 		for _, th := range txhashes {
@@ -232,6 +256,11 @@ func (rc *RichClient) GetAccountTokenTransfers(address types.Address, tokenIdent
 	for i, tte := range tteList.List {
 		rate := blkhashToRateMap[tte.BlockHash]
 		tteList.List[i].RevertRate = rate
+		tteList.List[i].TransferType = transferType
+	}
+
+	if rc.tokenReputation != nil {
+		rc.flagTokenTransferEvents(tteList)
 	}
 	return tteList, nil
 }
@@ -272,6 +301,51 @@ func (rc *RichClient) CreateSendTokenTransaction(from types.Address, to types.Ad
 	return &tx, nil
 }
 
+// CreateSendTokenTransactionByDecimalAmount creates unsigned transaction for sending token like CreateSendTokenTransaction,
+// but the amount is a decimal string in token unit such as "1.5", which is converted to base unit exactly
+// by the token decimals read on chain. The decimals is 18 for CFX when tokenIdentifier is nil.
+// It returns error if the decimals of token could not be read.
+func (rc *RichClient) CreateSendTokenTransactionByDecimalAmount(from types.Address, to types.Address, amount string, tokenIdentifier *types.Address) (*types.UnsignedTransaction, error) {
+	decimals := uint64(richtypes.CFXDecimal)
+	if tokenIdentifier != nil {
+		cInfo, err := rc.GetContractInfo(*tokenIdentifier, true, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get contract info of %v error", tokenIdentifier)
+		}
+
+		// the decimals of contract info is 0 if token info is not got from contract manager,
+		// so read it on chain to avoid sending the amount in base unit
+		decimals, err = rc.getTokenDecimals(*tokenIdentifier, cInfo.ABI)
+		if err != nil {
+			return nil, errors.Wrapf(err, "decimals of token %v is unknown", tokenIdentifier)
+		}
+	}
+
+	value, err := richtypes.ParseUnits(amount, decimals)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse amount %v with decimals %v error", amount, decimals)
+	}
+	if value.Sign() < 0 {
+		return nil, fmt.Errorf("amount %v is negative", amount)
+	}
+
+	return rc.CreateSendTokenTransaction(from, to, types.NewBigIntByRaw(value), tokenIdentifier)
+}
+
+// getTokenDecimals reads decimals of token by calling method "decimals" of the token contract
+func (rc *RichClient) getTokenDecimals(tokenIdentifier types.Address, abiJSON string) (uint64, error) {
+	contract, err := rc.client.GetContract([]byte(abiJSON), &tokenIdentifier)
+	if err != nil {
+		return 0, errors.Wrapf(err, "get contract by ABI {%+v}, tokenIdentifier {%+v} error", abiJSON, tokenIdentifier)
+	}
+
+	var decimals uint8
+	if err = contract.Call(nil, &decimals, "decimals"); err != nil {
+		return 0, errors.Wrapf(err, "call decimals of token %v error", tokenIdentifier)
+	}
+	return uint64(decimals), nil
+}
+
 func (rc *RichClient) getDataForTransToken(contractType richtypes.ContractType, contract sdk.Contractor, to types.Address, amount *hexutil.Big) ([]byte, error) {
 	var data []byte
 	var err error
@@ -313,13 +387,44 @@ func (rc *RichClient) getDataForTransToken(contractType richtypes.ContractType,
 
 // GetContractInfo returns contract detail infomation, it will contains token info if it is token contract,
 // it will contains abi if set needABI to be true.
+//
+// The token info comes from token lists set by SetTokenListRegistry ahead of contract manager,
+// and contract manager is not requested if the abi is not needed and the token is in token lists.
+//
+// The icon is returned as validated data URI from disk cache, data URI logoURI of token lists or contract manager
+// if needIcon is true, it is empty if the token has no icon. Remote logoURI is only fetched by GetTokenIcon.
 func (rc *RichClient) GetContractInfo(contractAddress types.Address, needABI, needIcon bool) (*richtypes.Contract, error) {
+	var result richtypes.Contract
+	if needABI || !rc.applyTokenList(contractAddress, &result.Token) {
+		contract, err := rc.getContractInfo(contractAddress, needABI)
+		if err != nil {
+			return nil, err
+		}
+
+		// copy to keep the cache unchanged by token lists and icon
+		result = *contract
+		rc.applyTokenList(contractAddress, &result.Token)
+	}
+
+	if needIcon {
+		icon, err := rc.getTokenIcon(contractAddress, false)
+		if err != nil && errors.Cause(err) != richtypes.ErrNotFound {
+			return nil, errors.Wrapf(err, "get icon of contract %v error", contractAddress)
+		}
+		if icon != nil {
+			result.TokenIcon = icon.DataURI()
+		}
+	}
+	return &result, nil
+}
+
+// getContractInfo returns contract info without icon from cache or contract manager
+func (rc *RichClient) getContractInfo(contractAddress types.Address, needABI bool) (*richtypes.Contract, error) {
 	params := make(map[string]interface{})
 
 	cInfoKey := contactInfoKey{
 		contractAddress: contractAddress.String(),
 		needABI:         needABI,
-		needIcon:        needIcon,
 	}
 	cInfoCache := contractInfoCaches[cInfoKey]
 	if cInfoCache != nil {
@@ -327,9 +432,6 @@ func (rc *RichClient) GetContractInfo(contractAddress types.Address, needABI, ne
 	}
 
 	fields := []string{}
-	if needIcon {
-		fields = append(fields, "icon")
-	}
 	if needABI {
 		fields = append(fields, "abi")
 	}
@@ -348,6 +450,8 @@ func (rc *RichClient) GetContractInfo(contractAddress types.Address, needABI, ne
 	var tokenQueryFullPath = fmt.Sprintf("%v/%v", tokenQueryBasePath, contractAddress)
 	rc.contractManager.Get(tokenQueryFullPath, params, &contract.Token)
 
+	// icon is got by GetTokenIcon and cached on disk, so it is not kept in memory
+	contract.TokenIcon = ""
 	contractInfoCaches[cInfoKey] = &contract
 
 	// fmt.Printf("get contract %v\n", cInfoKey)
@@ -355,7 +459,8 @@ func (rc *RichClient) GetContractInfo(contractAddress types.Address, needABI, ne
 	return &contract, nil
 }
 
-// GetAccountTokens returns coin balance and all token balances of specified address
+// GetAccountTokens returns coin balance and all token balances of specified address,
+// token icons are validated data URIs as GetContractInfo returns, or empty if invalid.
 func (rc *RichClient) GetAccountTokens(account types.Address) (*richtypes.TokenWithBlanceList, error) {
 	params := make(map[string]interface{})
 	params["accountAddress"] = account
@@ -367,6 +472,14 @@ func (rc *RichClient) GetAccountTokens(account types.Address) (*richtypes.TokenW
 		return nil, errors.Wrapf(err, msg)
 	}
 
+	for i := range tbs.List {
+		rc.applyTokenList(tbs.List[i].Address, &tbs.List[i].Token)
+		tbs.List[i].TokenIcon = rc.normalizeTokenIcon(tbs.List[i].Address, tbs.List[i].TokenIcon)
+	}
+
+	if rc.tokenReputation != nil {
+		rc.flagTokenBalances(&tbs)
+	}
 	return &tbs, nil
 }
 
@@ -426,11 +539,35 @@ func (rc *RichClient) GetTxDictsByEpoch(epoch *types.Epoch) ([]richtypes.TxDict,
 
 	//fmt.Println("create block and reverrate cache done, passed time: %", time.Now().Sub(start))
 
-	txdict, errs := rc.createTxDictsByBlockhashes(blockhashes, cache)
+	tc, err := NewTxDictConverter(rc)
+	if err != nil {
+		return nil, errors.Wrap(err, "create TxDictConverter error")
+	}
+
+	// the transactions failed to convert are skipped
+	txdict, _ := rc.createTxDictsByBlockhashes(tc, blockhashes, cache, nil)
+	//fmt.Println("create tx dic done, passed time: %", time.Now().Sub(start))
+	return txdict, nil
+}
+
+// getTxDictsByBlockhashes returns all cfx transfers and token transfers of blocks in order of blockhashes and transaction index,
+// only transactions touching addresses of filter are converted if filter is not nil.
+// Unlike GetTxDictsByEpoch, it fails if any transaction failed to convert, so that no transaction is missed.
+func (rc *RichClient) getTxDictsByBlockhashes(blockhashes []types.Hash, filter *AddressFilter) ([]richtypes.TxDict, error) {
+	cache, errs := createBlockAndRevertrateCache(rc.GetClient(), blockhashes)
 	if errs != nil {
 		return nil, joinError(errs)
 	}
-	//fmt.Println("create tx dic done, passed time: %", time.Now().Sub(start))
+
+	tc, err := NewTxDictConverter(rc)
+	if err != nil {
+		return nil, errors.Wrap(err, "create TxDictConverter error")
+	}
+
+	txdict, errs := rc.createTxDictsByBlockhashes(tc, blockhashes, cache, filter)
+	if len(errs) > 0 {
+		return nil, joinError(errs)
+	}
 	return txdict, nil
 }
 
@@ -438,6 +575,12 @@ func createBlockAndRevertrateCache(client sdk.ClientOperator, blockhashes []type
 	// cache block and it's revertrate
 	cache := make(map[types.Hash]*blockAndRevertrate)
 	var errs []error
+	var mutex sync.Mutex
+	appendError := func(err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		errs = append(errs, err)
+	}
 
 	// blockhashes = []types.Hash{"0x28d5a5b1b8f6c83e274b7ba1f027d16215596f27ea5effb745994401d23f8a18"}
 	// concurrence get block and revertrate
@@ -445,20 +588,22 @@ func createBlockAndRevertrateCache(client sdk.ClientOperator, blockhashes []type
 	wg.Add(len(blockhashes) * 2)
 
 	for _, blockhash := range blockhashes {
-		cache[blockhash] = &blockAndRevertrate{}
+		entry := &blockAndRevertrate{}
+		cache[blockhash] = entry
 
 		go func(bh types.Hash) {
 			defer wg.Done()
 
 			block, err := client.GetBlockByHash(bh)
 			if err != nil {
-				msg := fmt.Sprintf("get block by hash %v error", bh)
-				if errs == nil {
-					errs = make([]error, 0)
-				}
-				errs = append(errs, errors.Wrapf(err, msg))
+				appendError(errors.Wrapf(err, "get block by hash %v error", bh))
+				return
+			}
+			if block == nil {
+				appendError(errors.Errorf("block %v not found", bh))
+				return
 			}
-			cache[bh].block = block
+			entry.block = block
 		}(blockhash)
 
 		// get risk rate and block time
@@ -467,13 +612,10 @@ func createBlockAndRevertrateCache(client sdk.ClientOperator, blockhashes []type
 
 			revertRate, err := client.GetBlockConfirmationRisk(bh)
 			if err != nil {
-				msg := fmt.Sprintf("get block revert rate by hash %v error", bh)
-				if errs == nil {
-					errs = make([]error, 0)
-				}
-				errs = append(errs, errors.Wrapf(err, msg))
+				appendError(errors.Wrapf(err, "get block revert rate by hash %v error", bh))
+				return
 			}
-			cache[bh].revertRate = revertRate
+			entry.revertRate = revertRate
 		}(blockhash)
 	}
 	wg.Wait()
@@ -481,25 +623,27 @@ func createBlockAndRevertrateCache(client sdk.ClientOperator, blockhashes []type
 	return cache, errs
 }
 
-func (rc *RichClient) createTxDictsByBlockhashes(blockhashes []types.Hash, cache map[types.Hash]*blockAndRevertrate) ([]richtypes.TxDict, []error) {
+// createTxDictsByBlockhashes returns TxDicts of transactions in blocks and errors of transactions failed to convert,
+// which are skipped in the returned TxDicts.
+func (rc *RichClient) createTxDictsByBlockhashes(tc *TxDictConverter, blockhashes []types.Hash, cache map[types.Hash]*blockAndRevertrate, filter *AddressFilter) ([]richtypes.TxDict, []error) {
 
 	var errors = make([]error, 0)
 
-	tc, err := NewTxDictConverter(rc)
-	if err != nil {
-		errors = append(errors, err)
-		return nil, errors
-	}
-
-	txDicts := make([]richtypes.TxDict, 0)
+	// keep txDicts in order of transactions
+	var txDictsInOrder []*richtypes.TxDict
 
 	txs := make([]types.Transaction, 0)
 	for _, blockhash := range blockhashes {
 		// fmt.Printf("cache[%v]= %+v\n", blockhash, cache[blockhash])
+		if cache[blockhash] == nil || cache[blockhash].block == nil {
+			errors = append(errors, fmt.Errorf("block %v is not cached", blockhash))
+			continue
+		}
 		txs = append(txs, cache[blockhash].block.Transactions...)
 	}
 
 	all := len(txs)
+	txDictsInOrder = make([]*richtypes.TxDict, all)
 	con := constants.RPCConcurrence
 	excuted := 0
 	for {
@@ -515,7 +659,7 @@ func (rc *RichClient) createTxDictsByBlockhashes(blockhashes []types.Hash, cache
 
 		for i := 0; i < con; i++ {
 
-			go func(_tx types.Transaction) {
+			go func(_tx types.Transaction, index int) {
 
 				defer wg.Done()
 				//fmt.Println("excute tx done:", excuted)
@@ -525,6 +669,10 @@ func (rc *RichClient) createTxDictsByBlockhashes(blockhashes []types.Hash, cache
 					return
 				}
 
+				if filter != nil && !filter.mayBeTouchedBy(&_tx) {
+					return
+				}
+
 				cacheVal := cache[*_tx.BlockHash]
 
 				blockTimeInU64 := hexutil.Uint64(cacheVal.block.Timestamp.ToInt().Uint64())
@@ -536,8 +684,11 @@ func (rc *RichClient) createTxDictsByBlockhashes(blockhashes []types.Hash, cache
 					errors = append(errors, err)
 					return
 				}
-				txDicts = append(txDicts, *txDict)
-			}(txs[excuted])
+				if filter != nil && !filter.isTouchedBy(txDict) {
+					return
+				}
+				txDictsInOrder[index] = txDict
+			}(txs[excuted], excuted)
 			excuted++
 			//fmt.Println("excuting tx :", excuted)
 		}
@@ -549,7 +700,13 @@ func (rc *RichClient) createTxDictsByBlockhashes(blockhashes []types.Hash, cache
 		}
 	}
 
-	return txDicts, nil
+	txDicts := make([]richtypes.TxDict, 0, len(txDictsInOrder))
+	for _, txDict := range txDictsInOrder {
+		if txDict != nil {
+			txDicts = append(txDicts, *txDict)
+		}
+	}
+	return txDicts, errors
 }
 
 func joinError(errs []error) error {
@@ -2,11 +2,19 @@ package walletsdk
 
 import (
 	"encoding/json"
+	"math/big"
 	"reflect"
 	"testing"
 
+	sdk "github.com/Conflux-Chain/go-conflux-sdk"
 	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/mock"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/resource/contract/abi"
 	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
 )
 
 func TestGet(t *testing.T) {
@@ -45,6 +53,91 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestGetResponseResult(t *testing.T) {
+	cases := map[string]string{
+		`{"code":0,"message":"good","result":{"name":"xiaohong"}}`: `{"name":"xiaohong"}`,
+		`{"code":0,"result":null,"name":"xiaohong"}`:               `{"code":0,"result":null,"name":"xiaohong"}`,
+		`{"name":"xiaohong","age":10}`:                             `{"name":"xiaohong","age":10}`,
+		`[{"name":"xiaohong"}]`:                                    `[{"name":"xiaohong"}]`,
+	}
+	for body, expect := range cases {
+		if actual := string(getResponseResult([]byte(body))); actual != expect {
+			t.Errorf("expect result %v of body %v, actual: %v", expect, body, actual)
+		}
+	}
+}
+
+// sendTokenClientMock mocks methods used by CreateSendTokenTransactionByDecimalAmount,
+// the decimals of token is unknown if decimals is nil
+type sendTokenClientMock struct {
+	sdk.ClientOperator
+	decimals *big.Int
+}
+
+func (c *sendTokenClientMock) GetContract(abiJSON []byte, deployedAt *types.Address) (*sdk.Contract, error) {
+	contract, err := sdk.NewContract(abiJSON, nil, deployedAt)
+	if err != nil {
+		return nil, err
+	}
+	contract.Client = c
+	return contract, nil
+}
+
+func (c *sendTokenClientMock) Call(request types.CallRequest, epoch *types.Epoch) (hexutil.Bytes, error) {
+	if c.decimals == nil {
+		return nil, errors.New("execution reverted")
+	}
+	return hexutil.Bytes(common.BigToHash(c.decimals).Bytes()), nil
+}
+
+func (c *sendTokenClientMock) CreateUnsignedTransaction(from types.Address, to types.Address, amount *hexutil.Big, data []byte) (types.UnsignedTransaction, error) {
+	tx := types.UnsignedTransaction{}
+	tx.From = &from
+	tx.To = &to
+	tx.Value = amount
+	tx.Data = data
+	return tx, nil
+}
+
+func TestCreateSendTokenTransactionByDecimalAmount(t *testing.T) {
+	from := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+	to := cfxaddress.MustNewFromHex("0x160ebef20c1f739957bf9eecd040bce699cc42c6", cfxaddress.NetowrkTypeMainnetID)
+	token := cfxaddress.MustNewFromHex("0x8a2c3ea16f0d2a37e4fd7bc0d2ea4c4cf1a6e5b1", cfxaddress.NetowrkTypeMainnetID)
+
+	// the token info is not got, so the decimals of contract info is 0
+	var httpRequester mock.HttpClientMock
+	rspBody, _ := json.Marshal(richtypes.ErrorResponse{Result: richtypes.Contract{ABI: abi.GetABI(richtypes.ERC20)}})
+	httpRequester.SetHandler("", string(rspBody))
+
+	client := &sendTokenClientMock{}
+	rc := &RichClient{
+		contractManager: &scanServer{Scheme: "http", Address: "test", HTTPRequester: &httpRequester},
+		client:          client,
+	}
+
+	if _, err := rc.CreateSendTokenTransactionByDecimalAmount(from, to, "100", &token); err == nil {
+		t.Fatal("expect error when decimals of token is unknown")
+	}
+
+	client.decimals = big.NewInt(6)
+	tx, err := rc.CreateSendTokenTransactionByDecimalAmount(from, to, "100", &token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	erc20, err := sdk.NewContract([]byte(abi.GetABI(richtypes.ERC20)), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect, err := erc20.GetData("transfer", to.MustGetCommonAddress(), big.NewInt(100000000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual([]byte(tx.Data), expect) {
+		t.Errorf("expect transfer 100 tokens of 6 decimals, actual data: %x", tx.Data)
+	}
+}
+
 // func TestGetTokenByIdentifier(t *testing.T) {
 // 	expect := scantypes.Response{
 // 		Code:    0,
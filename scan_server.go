@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"net/url"
 
 	sdk "github.com/Conflux-Chain/go-conflux-sdk"
@@ -46,6 +47,10 @@ func (s *scanServer) Get(path string, params map[string]interface{}, unmarshaled
 		rspBytes.Body.Close()
 	}()
 
+	if rspBytes.StatusCode == http.StatusNotFound {
+		return errors.Wrapf(richtypes.ErrNotFound, "path %v", path)
+	}
+
 	body, err := ioutil.ReadAll(rspBytes.Body)
 	if err != nil {
 		return err
@@ -66,6 +71,7 @@ func (s *scanServer) Get(path string, params map[string]interface{}, unmarshaled
 	}
 
 	// unmarshl to result
+	body = getResponseResult(body)
 	err = json.Unmarshal(body, unmarshaledResult)
 	if err != nil {
 		return fmt.Errorf("failed to unmarshal '%v' to unmarshaledResult, error:%v", string(body), err.Error())
@@ -73,3 +79,15 @@ func (s *scanServer) Get(path string, params map[string]interface{}, unmarshaled
 	// fmt.Printf("unmarshaled result: %+v\n\n", unmarshaledResult)
 	return nil
 }
+
+// getResponseResult returns field "result" of response body in format of richtypes.ErrorResponse,
+// the body is returned directly if it has no field "result" or the result is null.
+func getResponseResult(body []byte) []byte {
+	var envelope struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil || len(envelope.Result) == 0 || string(envelope.Result) == "null" {
+		return body
+	}
+	return envelope.Result
+}
@@ -0,0 +1,159 @@
+package walletsdk
+
+import (
+	"fmt"
+	"math/big"
+
+	sdk "github.com/Conflux-Chain/go-conflux-sdk"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// CreateSendTokenTransactionWithOption creates unsigned transaction for sending token like CreateSendTokenTransaction,
+// the gas price, nonce, storage limit, epoch height and chain id could be specified by option.
+//
+// The gas and storage limit are estimated by cfx_estimateGasAndCollateral if they are not specified.
+// If option.CheckBalance is true, it checks that sender holds enough CFX for value, gas fee and storage collateral not covered by sponsor of
+// the token contract, and enough token balance through balanceOf when tokenIdentifier is not nil,
+// a *richtypes.InsufficientFundsError is returned if not.
+func (rc *RichClient) CreateSendTokenTransactionWithOption(from types.Address, to types.Address, amount *hexutil.Big, tokenIdentifier *types.Address, option *richtypes.SendTokenOption) (*types.UnsignedTransaction, error) {
+	tx, tokenContract, err := rc.createSendTokenTransaction(from, to, amount, tokenIdentifier, option)
+	if err != nil {
+		return nil, err
+	}
+
+	if option == nil || !option.CheckBalance {
+		return tx, nil
+	}
+
+	required := map[string]*big.Int{}
+	if tokenIdentifier != nil && amount != nil {
+		required[tokenIdentifier.String()] = amount.ToInt()
+	}
+	tokenContracts := map[string]sdk.Contractor{}
+	if tokenIdentifier != nil {
+		tokenContracts[tokenIdentifier.String()] = tokenContract
+	}
+
+	cfxRequired, err := rc.getSenderMaxCost(tx)
+	if err != nil {
+		return nil, errors.Wrap(err, "estimate fee error")
+	}
+
+	if err := rc.checkBalanceForSending(from, cfxRequired, required, tokenContracts); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// createSendTokenTransaction creates unsigned transaction for sending token without checking balance,
+// it also returns the token contract when tokenIdentifier is not nil.
+func (rc *RichClient) createSendTokenTransaction(from types.Address, to types.Address, amount *hexutil.Big, tokenIdentifier *types.Address, option *richtypes.SendTokenOption) (*types.UnsignedTransaction, sdk.Contractor, error) {
+	tx := new(types.UnsignedTransaction)
+	tx.From = &from
+
+	var tokenContract sdk.Contractor
+	if tokenIdentifier == nil {
+		tx.To = &to
+		tx.Value = amount
+	} else {
+		cInfo, err := rc.GetContractInfo(*tokenIdentifier, true, false)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "get contract info of %v error", tokenIdentifier)
+		}
+
+		contract, err := rc.client.GetContract([]byte(cInfo.ABI), tokenIdentifier)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "get contract by ABI {%+v}, tokenIdentifier {%+v} error", cInfo.ABI, tokenIdentifier)
+		}
+		tokenContract = contract
+
+		data, err := rc.getDataForTransToken(cInfo.GetContractTypeByABI(), contract, to, amount)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "get data for transfer token method error, contract type {%+v} ", cInfo.GetContractTypeByABI())
+		}
+
+		tokenAddress := *tokenIdentifier
+		tx.To = &tokenAddress
+		tx.Data = data
+	}
+
+	if err := rc.applySendTokenOption(tx, option); err != nil {
+		return nil, nil, errors.Wrapf(err, "apply option %+v error", option)
+	}
+
+	// fill others by conflux node, the gas and storage limit are estimated by cfx_estimateGasAndCollateral
+	if err := rc.client.ApplyUnsignedTransactionDefault(tx); err != nil {
+		msg := fmt.Sprintf("create transaction with params {from: %+v, to: %+v, data: %+v} error ", from, tx.To, tx.Data)
+		return nil, nil, errors.Wrap(err, msg)
+	}
+
+	return tx, tokenContract, nil
+}
+
+func (rc *RichClient) applySendTokenOption(tx *types.UnsignedTransaction, option *richtypes.SendTokenOption) error {
+	if option == nil {
+		return nil
+	}
+
+	tx.Nonce = option.Nonce
+	tx.StorageLimit = option.StorageLimit
+	tx.EpochHeight = option.EpochHeight
+	tx.ChainID = option.ChainID
+	tx.GasPrice = option.GasPrice
+
+	if tx.GasPrice == nil && option.GasPriceStrategy != nil {
+		gasPrice, err := option.GasPriceStrategy(rc.client)
+		if err != nil {
+			return errors.Wrap(err, "get gas price by strategy error")
+		}
+		tx.GasPrice = gasPrice
+	}
+	return nil
+}
+
+// checkBalanceForSending checks CFX balance of account for cfxRequired and token balances for tokenRequired,
+// the keys of tokenRequired and tokenContracts are token contract addresses.
+func (rc *RichClient) checkBalanceForSending(account types.Address, cfxRequired *big.Int, tokenRequired map[string]*big.Int, tokenContracts map[string]sdk.Contractor) error {
+	balance, err := rc.client.GetBalance(account)
+	if err != nil {
+		return errors.Wrapf(err, "get balance of %v error", account)
+	}
+
+	if balance.ToInt().Cmp(cfxRequired) < 0 {
+		return &richtypes.InsufficientFundsError{
+			Address:  account,
+			Required: cfxRequired,
+			Balance:  balance.ToInt(),
+		}
+	}
+
+	for tokenKey, required := range tokenRequired {
+		tokenContract := tokenContracts[tokenKey]
+
+		tokenBalance := new(big.Int)
+		if err := tokenContract.Call(nil, &tokenBalance, "balanceOf", account.MustGetCommonAddress()); err != nil {
+			return errors.Wrapf(err, "get token balance of %v on %v error", account, tokenKey)
+		}
+
+		if tokenBalance.Cmp(required) < 0 {
+			tokenIdentifier := cfxaddress.MustNew(tokenKey)
+			tokenCode := ""
+			if cInfo, err := rc.GetContractInfo(tokenIdentifier, true, false); err == nil {
+				tokenCode = cInfo.TokenSymbol
+			}
+			return &richtypes.InsufficientFundsError{
+				Address:         account,
+				TokenIdentifier: &tokenIdentifier,
+				TokenCode:       tokenCode,
+				Required:        required,
+				Balance:         tokenBalance,
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package walletsdk
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	sdk "github.com/Conflux-Chain/go-conflux-sdk"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/mock"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/resource/contract/abi"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/constants"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// balanceClientMock mocks methods used by applySendTokenOption and checkBalanceForSending,
+// the token balance is returned for all contract calls
+type balanceClientMock struct {
+	sdk.ClientOperator
+	gasPrice     *big.Int
+	balance      *big.Int
+	tokenBalance *big.Int
+}
+
+func (c *balanceClientMock) GetGasPrice() (*hexutil.Big, error) {
+	return types.NewBigIntByRaw(c.gasPrice), nil
+}
+
+func (c *balanceClientMock) GetBalance(address types.Address, epoch ...*types.Epoch) (*hexutil.Big, error) {
+	return types.NewBigIntByRaw(c.balance), nil
+}
+
+func (c *balanceClientMock) Call(request types.CallRequest, epoch *types.Epoch) (hexutil.Bytes, error) {
+	return hexutil.Bytes(common.BigToHash(c.tokenBalance).Bytes()), nil
+}
+
+func TestApplySendTokenOption(t *testing.T) {
+	client := &balanceClientMock{gasPrice: big.NewInt(3000)}
+	rc := &RichClient{client: client}
+
+	nonce := types.NewBigInt(5)
+	storageLimit := hexutil.Uint64(64)
+	epochHeight := hexutil.Uint64(100)
+	chainID := hexutil.Uint(1029)
+	option := &richtypes.SendTokenOption{
+		Nonce:            nonce,
+		StorageLimit:     &storageLimit,
+		EpochHeight:      &epochHeight,
+		ChainID:          &chainID,
+		GasPriceStrategy: richtypes.ScaledGasPrice(200),
+	}
+
+	tx := new(types.UnsignedTransaction)
+	if err := rc.applySendTokenOption(tx, option); err != nil {
+		t.Fatal(err)
+	}
+	if tx.Nonce.ToInt().Cmp(big.NewInt(5)) != 0 || *tx.StorageLimit != storageLimit || *tx.EpochHeight != epochHeight || *tx.ChainID != chainID {
+		t.Errorf("expect nonce, storage limit, epoch height and chain id of option %+v, actual: %+v", option, tx)
+	}
+	if tx.GasPrice.ToInt().Cmp(big.NewInt(6000)) != 0 {
+		t.Errorf("expect gas price scaled to 6000, actual: %v", tx.GasPrice)
+	}
+
+	// the scaled gas price is not less than the min gas price
+	client.gasPrice = big.NewInt(5)
+	option.GasPriceStrategy = richtypes.ScaledGasPrice(10)
+	tx = new(types.UnsignedTransaction)
+	if err := rc.applySendTokenOption(tx, option); err != nil {
+		t.Fatal(err)
+	}
+	if tx.GasPrice.ToInt().Cmp(big.NewInt(constants.MinGasprice)) != 0 {
+		t.Errorf("expect min gas price %v, actual: %v", constants.MinGasprice, tx.GasPrice)
+	}
+
+	// gas price of option takes precedence over strategy
+	option.GasPrice = types.NewBigInt(2000)
+	option.GasPriceStrategy = richtypes.FixedGasPrice(types.NewBigInt(4000))
+	tx = new(types.UnsignedTransaction)
+	if err := rc.applySendTokenOption(tx, option); err != nil {
+		t.Fatal(err)
+	}
+	if tx.GasPrice.ToInt().Cmp(big.NewInt(2000)) != 0 {
+		t.Errorf("expect gas price 2000 of option, actual: %v", tx.GasPrice)
+	}
+
+	option.GasPrice = nil
+	tx = new(types.UnsignedTransaction)
+	if err := rc.applySendTokenOption(tx, option); err != nil {
+		t.Fatal(err)
+	}
+	if tx.GasPrice.ToInt().Cmp(big.NewInt(4000)) != 0 {
+		t.Errorf("expect fixed gas price 4000, actual: %v", tx.GasPrice)
+	}
+}
+
+func TestCheckBalanceForSending(t *testing.T) {
+	account := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+	token := cfxaddress.MustNewFromHex("0x8e6b6c3a1e1ad1a7b0e6c5f1b9d8e4a3c2b1a0f9", cfxaddress.NetowrkTypeMainnetID)
+
+	var httpRequester mock.HttpClientMock
+	rspBody, _ := json.Marshal(richtypes.ErrorResponse{Result: richtypes.Token{TokenSymbol: "TK", TokenDecimal: 18}})
+	httpRequester.SetHandler("", string(rspBody))
+
+	client := &balanceClientMock{balance: big.NewInt(1000), tokenBalance: big.NewInt(50)}
+	rc := &RichClient{
+		contractManager: &scanServer{Scheme: "http", Address: "test", HTTPRequester: &httpRequester},
+		client:          client,
+	}
+
+	tokenContract, err := sdk.NewContract([]byte(abi.GetABI(richtypes.ERC20)), nil, &token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokenContract.Client = client
+	tokenContracts := map[string]sdk.Contractor{token.String(): tokenContract}
+
+	if err := rc.checkBalanceForSending(account, big.NewInt(1000), map[string]*big.Int{token.String(): big.NewInt(50)}, tokenContracts); err != nil {
+		t.Fatalf("expect enough balance, actual: %v", err)
+	}
+
+	// insufficient cfx
+	err = rc.checkBalanceForSending(account, big.NewInt(1001), map[string]*big.Int{token.String(): big.NewInt(50)}, tokenContracts)
+	fundsErr, ok := err.(*richtypes.InsufficientFundsError)
+	if !ok {
+		t.Fatalf("expect InsufficientFundsError, actual: %v", err)
+	}
+	if fundsErr.TokenIdentifier != nil || fundsErr.Address.String() != account.String() ||
+		fundsErr.Required.Cmp(big.NewInt(1001)) != 0 || fundsErr.Balance.Cmp(big.NewInt(1000)) != 0 {
+		t.Errorf("expect insufficient cfx of %v, actual: %+v", account, fundsErr)
+	}
+
+	// insufficient token
+	err = rc.checkBalanceForSending(account, big.NewInt(1000), map[string]*big.Int{token.String(): big.NewInt(51)}, tokenContracts)
+	fundsErr, ok = err.(*richtypes.InsufficientFundsError)
+	if !ok {
+		t.Fatalf("expect InsufficientFundsError, actual: %v", err)
+	}
+	if fundsErr.TokenIdentifier == nil || fundsErr.TokenIdentifier.String() != token.String() || fundsErr.TokenCode != "TK" ||
+		fundsErr.Required.Cmp(big.NewInt(51)) != 0 || fundsErr.Balance.Cmp(big.NewInt(50)) != 0 {
+		t.Errorf("expect insufficient token %v, actual: %+v", token, fundsErr)
+	}
+}
@@ -0,0 +1,114 @@
+package walletsdk
+
+import (
+	"math/big"
+
+	richconstants "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/constants"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/resource/contract/abi"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// GetSponsorInfo returns sponsors of contract and whether sender is in the whitelist of contract,
+// the whitelist is not checked if sender is nil.
+func (rc *RichClient) GetSponsorInfo(contract types.Address, sender *types.Address) (*richtypes.SponsorInfo, error) {
+	sponsor, err := rc.client.GetSponsorInfo(contract)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get sponsor info of %v error", contract)
+	}
+
+	info := &richtypes.SponsorInfo{
+		Contract:                    contract,
+		SponsorGasBound:             sponsor.SponsorGasBound.ToInt(),
+		SponsorBalanceForGas:        sponsor.SponsorBalanceForGas.ToInt(),
+		SponsorBalanceForCollateral: sponsor.SponsorBalanceForCollateral.ToInt(),
+		Sender:                      sender,
+	}
+	// the zero address means no sponsor
+	if sponsor.SponsorForGas.MustGetCommonAddress() != (common.Address{}) {
+		info.SponsorForGas = &sponsor.SponsorForGas
+	}
+	if sponsor.SponsorForCollateral.MustGetCommonAddress() != (common.Address{}) {
+		info.SponsorForCollateral = &sponsor.SponsorForCollateral
+	}
+
+	if sender == nil {
+		return info, nil
+	}
+
+	controlAddress := cfxaddress.MustNewFromCommon(richconstants.SponsorWhitelistControlHexAddress, contract.GetNetworkID())
+	control, err := rc.client.GetContract([]byte(abi.GetABI(richtypes.SPONSOR)), &controlAddress)
+	if err != nil {
+		return nil, errors.Wrap(err, "get SponsorWhitelistControl contract error")
+	}
+
+	if err := control.Call(nil, &info.IsWhitelisted, "isWhitelisted", contract.MustGetCommonAddress(), sender.MustGetCommonAddress()); err != nil {
+		return nil, errors.Wrapf(err, "check whether %v is whitelisted by %v error", sender, contract)
+	}
+	return info, nil
+}
+
+// EstimateFee returns gas fee and storage collateral of unsigned transaction, and which of them are paid by sponsor
+// if the transaction calls a sponsored contract. The gas, gas price and storage limit of tx should be filled.
+func (rc *RichClient) EstimateFee(tx *types.UnsignedTransaction) (*richtypes.FeeEstimate, error) {
+	fee := getFeeEstimate(tx, nil)
+	if tx.From == nil || tx.To == nil || tx.To.GetAddressType() != cfxaddress.AddressTypeContract {
+		return fee, nil
+	}
+
+	sponsor, err := rc.GetSponsorInfo(*tx.To, tx.From)
+	if err != nil {
+		return nil, err
+	}
+	return getFeeEstimate(tx, sponsor), nil
+}
+
+// getFeeEstimate calculates fee of tx, the gas fee is covered if sender is whitelisted and it is not greater than
+// both of gas bound and balance for gas of sponsor, and the storage collateral is covered if sender is whitelisted
+// and it is not greater than balance for collateral of sponsor.
+func getFeeEstimate(tx *types.UnsignedTransaction, sponsor *richtypes.SponsorInfo) *richtypes.FeeEstimate {
+	fee := &richtypes.FeeEstimate{
+		GasFee:            new(big.Int),
+		StorageCollateral: new(big.Int),
+	}
+	if tx.Gas != nil && tx.GasPrice != nil {
+		fee.GasFee.Mul(tx.Gas.ToInt(), tx.GasPrice.ToInt())
+	}
+	if tx.StorageLimit != nil {
+		fee.StorageCollateral.SetUint64(uint64(*tx.StorageLimit))
+		fee.StorageCollateral.Mul(fee.StorageCollateral, big.NewInt(richconstants.CollateralDripPerStorageByte))
+	}
+
+	if sponsor != nil && sponsor.IsWhitelisted {
+		fee.GasCoveredBySponsor = sponsor.SponsorForGas != nil &&
+			fee.GasFee.Cmp(sponsor.SponsorGasBound) <= 0 && fee.GasFee.Cmp(sponsor.SponsorBalanceForGas) <= 0
+		fee.StorageCoveredBySponsor = sponsor.SponsorForCollateral != nil &&
+			fee.StorageCollateral.Cmp(sponsor.SponsorBalanceForCollateral) <= 0
+	}
+
+	fee.SenderFee = new(big.Int)
+	if !fee.GasCoveredBySponsor {
+		fee.SenderFee.Add(fee.SenderFee, fee.GasFee)
+	}
+	if !fee.StorageCoveredBySponsor {
+		fee.SenderFee.Add(fee.SenderFee, fee.StorageCollateral)
+	}
+	return fee
+}
+
+// getSenderMaxCost returns value and the fee paid by sender of tx in drip, the part covered by sponsor is excluded
+func (rc *RichClient) getSenderMaxCost(tx *types.UnsignedTransaction) (*big.Int, error) {
+	fee, err := rc.EstimateFee(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	cost := new(big.Int).Set(fee.SenderFee)
+	if tx.Value != nil {
+		cost.Add(cost, tx.Value.ToInt())
+	}
+	return cost, nil
+}
@@ -0,0 +1,51 @@
+package walletsdk
+
+import (
+	"math/big"
+	"testing"
+
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestGetFeeEstimate(t *testing.T) {
+	storageLimit := hexutil.Uint64(64)
+	tx := new(types.UnsignedTransaction)
+	tx.Gas = types.NewBigInt(30000)
+	tx.GasPrice = types.NewBigInt(1000)
+	tx.StorageLimit = &storageLimit
+
+	sponsorAddress := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+	sponsor := &richtypes.SponsorInfo{
+		SponsorForGas:               &sponsorAddress,
+		SponsorForCollateral:        &sponsorAddress,
+		SponsorGasBound:             big.NewInt(1e8),
+		SponsorBalanceForGas:        big.NewInt(1e10),
+		SponsorBalanceForCollateral: big.NewInt(0),
+		IsWhitelisted:               true,
+	}
+
+	fee := getFeeEstimate(tx, sponsor)
+	if !fee.GasCoveredBySponsor || fee.StorageCoveredBySponsor {
+		t.Fatalf("expect only gas covered by sponsor, actual: %+v", fee)
+	}
+	if fee.SenderFee.Cmp(fee.StorageCollateral) != 0 {
+		t.Errorf("expect sender fee %v, actual: %v", fee.StorageCollateral, fee.SenderFee)
+	}
+
+	// gas fee exceeds upper bound of sponsor
+	sponsor.SponsorGasBound = big.NewInt(1000)
+	fee = getFeeEstimate(tx, sponsor)
+	if fee.GasCoveredBySponsor {
+		t.Errorf("expect gas not covered when exceeding gas bound, actual: %+v", fee)
+	}
+
+	sponsor.IsWhitelisted = false
+	sponsor.SponsorGasBound = big.NewInt(1e8)
+	fee = getFeeEstimate(tx, sponsor)
+	if fee.GasCoveredBySponsor || fee.SenderFee.Cmp(new(big.Int).Add(fee.GasFee, fee.StorageCollateral)) != 0 {
+		t.Errorf("expect nothing covered for sender not whitelisted, actual: %+v", fee)
+	}
+}
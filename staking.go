@@ -0,0 +1,118 @@
+package walletsdk
+
+import (
+	"math/big"
+	"sort"
+
+	richconstants "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/constants"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/helper"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/constants"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/pkg/errors"
+)
+
+// GetStakingInfo returns staking balance, locked staking balance at latest block and unlock schedule of account
+func (rc *RichClient) GetStakingInfo(account types.Address) (*richtypes.StakingInfo, error) {
+	stakingBalance, err := rc.client.GetStakingBalance(account)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get staking balance of %v error", account)
+	}
+
+	voteList, err := rc.client.GetVoteList(account)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get vote list of %v error", account)
+	}
+
+	status, err := rc.client.GetStatus()
+	if err != nil {
+		return nil, errors.Wrap(err, "get status error")
+	}
+
+	info := getStakingInfoByVoteList(voteList, uint64(status.BlockNumber))
+	info.StakingBalance = stakingBalance.ToInt()
+	return info, nil
+}
+
+// getStakingInfoByVoteList calculates locked staking balance at blockNumber and unlock schedule after it,
+// the amount of vote is locked before its unlock block number, so the locked balance at a block is
+// the max amount of votes unlocked after the block.
+func getStakingInfoByVoteList(voteList []types.VoteStakeInfo, blockNumber uint64) *richtypes.StakingInfo {
+	votes := make([]types.VoteStakeInfo, 0, len(voteList))
+	for _, vote := range voteList {
+		if uint64(vote.UnlockBlockNumber) > blockNumber && vote.Amount != nil {
+			votes = append(votes, vote)
+		}
+	}
+	sort.Slice(votes, func(i, j int) bool { return votes[i].UnlockBlockNumber < votes[j].UnlockBlockNumber })
+
+	// lockedAfter[i] is the locked balance after votes[i] is unlocked
+	lockedAfter := make([]*big.Int, len(votes)+1)
+	lockedAfter[len(votes)] = big.NewInt(0)
+	for i := len(votes) - 1; i >= 0; i-- {
+		lockedAfter[i] = lockedAfter[i+1]
+		if votes[i].Amount.ToInt().Cmp(lockedAfter[i]) > 0 {
+			lockedAfter[i] = votes[i].Amount.ToInt()
+		}
+	}
+
+	info := &richtypes.StakingInfo{
+		LockedStakingBalance: new(big.Int).Set(lockedAfter[0]),
+		BlockNumber:          blockNumber,
+		UnlockSchedule:       make([]richtypes.StakingUnlock, 0),
+	}
+
+	for i := range votes {
+		unlocked := new(big.Int).Sub(lockedAfter[i], lockedAfter[i+1])
+		if unlocked.Sign() == 0 {
+			continue
+		}
+
+		unlockBlockNumber := uint64(votes[i].UnlockBlockNumber)
+		if n := len(info.UnlockSchedule); n > 0 && info.UnlockSchedule[n-1].UnlockBlockNumber == unlockBlockNumber {
+			info.UnlockSchedule[n-1].Amount.Add(info.UnlockSchedule[n-1].Amount, unlocked)
+			continue
+		}
+		info.UnlockSchedule = append(info.UnlockSchedule, richtypes.StakingUnlock{Amount: unlocked, UnlockBlockNumber: unlockBlockNumber})
+	}
+	return info
+}
+
+// fillTxDictByStakingFunction fills staking movement according to data of transaction sent to Staking
+func (tc *TxDictConverter) fillTxDictByStakingFunction(txDictBase *richtypes.TxDictBase, from *types.Address, data []byte, sn *uint64) {
+	funcParams, err := tc.decoder.DecodeStakingFunction(data)
+	if err != nil || from == nil {
+		return
+	}
+
+	staking := helper.MustNewCfxAddressPtr(&richconstants.StakingHexAddress, tc.networkID)
+	switch params := funcParams.(type) {
+	case *richtypes.StakingDepositFunctionParams:
+		tc.appendStakingUnits(txDictBase, from, staking, params.Amount, richtypes.StakingDeposit, sn)
+	case *richtypes.StakingWithdrawFunctionParams:
+		tc.appendStakingUnits(txDictBase, staking, from, params.Amount, richtypes.StakingWithdraw, sn)
+	case *richtypes.StakingVoteLockFunctionParams:
+		tc.appendStakingUnits(txDictBase, from, from, params.Amount, richtypes.StakingVoteLock, sn)
+	}
+}
+
+func (tc *TxDictConverter) appendStakingUnits(txDictBase *richtypes.TxDictBase, from, to *types.Address, value *big.Int, action richtypes.StakingAction, sn *uint64) {
+	if value == nil || value.Sign() == 0 {
+		return
+	}
+
+	newUnit := func(address *types.Address) richtypes.TxUnit {
+		return richtypes.TxUnit{
+			Value:         new(big.Int).Set(value),
+			Address:       address,
+			Sn:            *sn,
+			TokenCode:     constants.CFXSymbol,
+			TokenDecimal:  constants.CFXDecimal,
+			StakingAction: action,
+		}
+	}
+
+	txDictBase.Inputs = append(txDictBase.Inputs, newUnit(from))
+	txDictBase.Outputs = append(txDictBase.Outputs, newUnit(to))
+	(*sn)++
+}
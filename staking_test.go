@@ -0,0 +1,92 @@
+package walletsdk
+
+import (
+	"math/big"
+	"testing"
+
+	sdk "github.com/Conflux-Chain/go-conflux-sdk"
+	richconstants "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/constants"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/resource/contract/abi"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestConvertStakingUnsignedTransaction(t *testing.T) {
+	converter, err := NewTxDictConverter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	staking, err := sdk.NewContract([]byte(abi.GetABI(richtypes.STAKING)), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+	to := cfxaddress.MustNewFromCommon(richconstants.StakingHexAddress, cfxaddress.NetowrkTypeMainnetID)
+
+	data, err := staking.GetData("deposit", big.NewInt(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := new(types.UnsignedTransaction)
+	tx.From = &from
+	tx.To = &to
+	tx.Value = types.NewBigInt(0)
+	tx.Data = data
+
+	txDictBase := converter.ConvertByUnsignedTransaction(tx)
+	if len(txDictBase.Outputs) != 2 {
+		t.Fatalf("expect 2 outputs, actual: %+v", txDictBase.Outputs)
+	}
+
+	input, output := txDictBase.Inputs[1], txDictBase.Outputs[1]
+	if input.StakingAction != richtypes.StakingDeposit || input.Address.String() != from.String() || input.Value.Cmp(big.NewInt(1000)) != 0 {
+		t.Errorf("expect deposit from %v, actual: %+v", from, input)
+	}
+	if output.Address.String() != to.String() {
+		t.Errorf("expect deposit to staking contract %v, actual: %v", to, output.Address)
+	}
+
+	// vote lock does not change balance
+	data, err = staking.GetData("voteLock", big.NewInt(500), big.NewInt(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Data = data
+
+	txDictBase = converter.ConvertByUnsignedTransaction(tx)
+	if len(txDictBase.Outputs) != 2 || txDictBase.Outputs[1].StakingAction != richtypes.StakingVoteLock ||
+		txDictBase.Outputs[1].Address.String() != from.String() {
+		t.Fatalf("expect vote lock of %v, actual: %+v", from, txDictBase.Outputs)
+	}
+}
+
+func TestGetStakingInfoByVoteList(t *testing.T) {
+	voteList := []types.VoteStakeInfo{
+		{Amount: (*hexutil.Big)(big.NewInt(100)), UnlockBlockNumber: 50},
+		{Amount: (*hexutil.Big)(big.NewInt(300)), UnlockBlockNumber: 200},
+		{Amount: (*hexutil.Big)(big.NewInt(200)), UnlockBlockNumber: 400},
+	}
+
+	info := getStakingInfoByVoteList(voteList, 100)
+	if info.LockedStakingBalance.Cmp(big.NewInt(300)) != 0 {
+		t.Fatalf("expect locked 300, actual: %v", info.LockedStakingBalance)
+	}
+
+	expect := []richtypes.StakingUnlock{
+		{Amount: big.NewInt(100), UnlockBlockNumber: 200},
+		{Amount: big.NewInt(200), UnlockBlockNumber: 400},
+	}
+	if len(info.UnlockSchedule) != len(expect) {
+		t.Fatalf("expect schedule %+v, actual: %+v", expect, info.UnlockSchedule)
+	}
+	for i := range expect {
+		if info.UnlockSchedule[i].Amount.Cmp(expect[i].Amount) != 0 || info.UnlockSchedule[i].UnlockBlockNumber != expect[i].UnlockBlockNumber {
+			t.Errorf("expect schedule %+v, actual: %+v", expect, info.UnlockSchedule)
+		}
+	}
+}
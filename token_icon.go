@@ -0,0 +1,201 @@
+package walletsdk
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/pkg/errors"
+)
+
+// TokenIconCache caches token icons on disk, icon files are named by sha256 of the content
+// and shared by tokens with the same icon, and each token has a reference file to its icon file.
+type TokenIconCache struct {
+	dir string
+}
+
+// NewTokenIconCache creates TokenIconCache in dir, the dir is created if not exists
+func NewTokenIconCache(dir string) (*TokenIconCache, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "tokens"), 0755); err != nil {
+		return nil, errors.Wrapf(err, "create token icon cache dir %v error", dir)
+	}
+	return &TokenIconCache{dir: dir}, nil
+}
+
+// Get returns cached icon of token, it returns nil if icon is not cached or the icon file is broken
+func (tic *TokenIconCache) Get(tokenAddress types.Address) *richtypes.TokenIcon {
+	ref, err := ioutil.ReadFile(tic.refPath(tokenAddress))
+	if err != nil {
+		return nil
+	}
+
+	fileName := strings.TrimSpace(string(ref))
+	data, err := ioutil.ReadFile(filepath.Join(tic.dir, filepath.Base(fileName)))
+	if err != nil {
+		return nil
+	}
+
+	icon, err := richtypes.NewTokenIcon(data, "")
+	if err != nil || icon.Hash+icon.Extension() != fileName {
+		return nil
+	}
+	return icon
+}
+
+// Put saves icon of token
+func (tic *TokenIconCache) Put(tokenAddress types.Address, icon *richtypes.TokenIcon) error {
+	fileName := icon.Hash + icon.Extension()
+	iconPath := filepath.Join(tic.dir, fileName)
+	if _, err := os.Stat(iconPath); os.IsNotExist(err) {
+		if err := writeFileAtomic(iconPath, icon.Data); err != nil {
+			return errors.Wrapf(err, "write icon file %v error", iconPath)
+		}
+	}
+
+	if err := writeFileAtomic(tic.refPath(tokenAddress), []byte(fileName)); err != nil {
+		return errors.Wrapf(err, "write icon reference of %v error", tokenAddress)
+	}
+	return nil
+}
+
+func (tic *TokenIconCache) refPath(tokenAddress types.Address) string {
+	return filepath.Join(tic.dir, "tokens", fmt.Sprintf("%v-%v", tokenAddress.GetNetworkID(), tokenAddress.MustGetCommonAddress().Hex()))
+}
+
+// writeFileAtomic writes data to a temp file and renames it to path, so that readers never get a partial file
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// SetTokenIconCache sets disk cache of token icons, icons are fetched every time if cache is nil
+func (rc *RichClient) SetTokenIconCache(cache *TokenIconCache) {
+	rc.tokenIcons = cache
+}
+
+// GetTokenIcon returns decoded and validated icon of token,
+// the icon comes from logoURI of token lists ahead of contract manager.
+func (rc *RichClient) GetTokenIcon(tokenAddress types.Address) (*richtypes.TokenIcon, error) {
+	return rc.getTokenIcon(tokenAddress, true)
+}
+
+// getTokenIcon returns icon of token from disk cache or fetches it, http(s) logoURI of token lists is
+// requested only if remote is true. The error cause is richtypes.ErrNotFound if token has no icon.
+func (rc *RichClient) getTokenIcon(tokenAddress types.Address, remote bool) (*richtypes.TokenIcon, error) {
+	if rc.tokenIcons != nil {
+		if icon := rc.tokenIcons.Get(tokenAddress); icon != nil {
+			return icon, nil
+		}
+	}
+
+	icon, err := rc.fetchTokenIcon(tokenAddress, remote)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetch icon of token %v error", tokenAddress)
+	}
+
+	if rc.tokenIcons != nil {
+		if err := rc.tokenIcons.Put(tokenAddress, icon); err != nil {
+			return nil, errors.Wrap(err, "cache token icon error")
+		}
+	}
+	return icon, nil
+}
+
+// fetchTokenIcon gets icon from logoURI of token lists or contract manager,
+// logoURI which is not data URI is skipped if remote is false.
+func (rc *RichClient) fetchTokenIcon(tokenAddress types.Address, remote bool) (*richtypes.TokenIcon, error) {
+	if rc.tokenLists != nil {
+		item := rc.tokenLists.GetTokenByAddress(tokenAddress)
+		if item != nil && item.LogoURI != "" && (remote || strings.HasPrefix(item.LogoURI, "data:")) {
+			return rc.fetchTokenIconByURI(item.LogoURI)
+		}
+	}
+
+	params := map[string]interface{}{"fields": "icon"}
+	var contract richtypes.Contract
+	contractQueryFullPath := fmt.Sprintf("%v/%v", contractQueryBasePath, tokenAddress)
+	if err := rc.contractManager.Get(contractQueryFullPath, params, &contract); err != nil {
+		return nil, errors.Wrapf(err, "get icon from ContractManager server and path {%+v} error", contractQueryFullPath)
+	}
+	if contract.TokenIcon == "" {
+		tokenQueryFullPath := fmt.Sprintf("%v/%v", tokenQueryBasePath, tokenAddress)
+		rc.contractManager.Get(tokenQueryFullPath, params, &contract.Token)
+	}
+	if contract.TokenIcon == "" {
+		return nil, errors.Wrapf(richtypes.ErrNotFound, "icon of token %v", tokenAddress)
+	}
+	return richtypes.ParseTokenIcon(contract.TokenIcon)
+}
+
+// fetchTokenIconByURI gets icon by data URI or http(s) URL
+func (rc *RichClient) fetchTokenIconByURI(uri string) (*richtypes.TokenIcon, error) {
+	if strings.HasPrefix(uri, "data:") {
+		return richtypes.ParseTokenIcon(uri)
+	}
+	if !strings.HasPrefix(uri, "http://") && !strings.HasPrefix(uri, "https://") {
+		return nil, fmt.Errorf("unsupported icon URI %v", uri)
+	}
+
+	rsp, err := rc.contractManager.HTTPRequester.Get(uri)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get icon %v error", uri)
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != 0 && rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get icon %v error, status: %v", uri, rsp.Status)
+	}
+
+	// read one more byte than the max limit to find oversized icon without reading all of it
+	maxLimit := 0
+	for _, limit := range richtypes.TokenIconSizeLimits {
+		if limit > maxLimit {
+			maxLimit = limit
+		}
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(rsp.Body, int64(maxLimit)+1))
+	if err != nil {
+		return nil, errors.Wrapf(err, "read icon %v error", uri)
+	}
+	if len(data) > maxLimit {
+		return nil, fmt.Errorf("size of icon %v exceeds limit %v", uri, maxLimit)
+	}
+	return richtypes.NewTokenIcon(data, "")
+}
+
+// normalizeTokenIcon returns icon of token as data URI from disk cache or the given icon URI,
+// it returns empty if the given icon is not a valid data URI.
+func (rc *RichClient) normalizeTokenIcon(tokenAddress types.Address, iconURI string) string {
+	if rc.tokenIcons != nil {
+		if icon := rc.tokenIcons.Get(tokenAddress); icon != nil {
+			return icon.DataURI()
+		}
+	}
+
+	if iconURI == "" {
+		return ""
+	}
+	icon, err := richtypes.ParseTokenIcon(iconURI)
+	if err != nil {
+		return ""
+	}
+	return icon.DataURI()
+}
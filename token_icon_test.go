@@ -0,0 +1,89 @@
+package walletsdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/mock"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+)
+
+func TestGetTokenIcon(t *testing.T) {
+	dir, err := ioutil.TempDir("", "token_icon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatal(err)
+	}
+	expect, err := richtypes.NewTokenIcon(buf.Bytes(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var httpRequester mock.HttpClientMock
+	rspBody, _ := json.Marshal(richtypes.ErrorResponse{Result: richtypes.Token{TokenIcon: expect.DataURI()}})
+	httpRequester.SetHandler("", string(rspBody))
+
+	cache, err := NewTokenIconCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc := &RichClient{
+		contractManager: &scanServer{Scheme: "http", Address: "test", HTTPRequester: &httpRequester},
+		tokenIcons:      cache,
+	}
+
+	token := cfxaddress.MustNewFromHex("0x8b8689c7f3014a4d86e4d1d0daaf74a47f5e0f27", cfxaddress.NetowrkTypeMainnetID)
+	icon, err := rc.GetTokenIcon(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if icon.MIMEType != richtypes.TokenIconPNG || icon.Hash != expect.Hash || !bytes.Equal(icon.Data, expect.Data) {
+		t.Fatalf("expect icon %v, actual: %v %v", expect.Hash, icon.MIMEType, icon.Hash)
+	}
+
+	// the icon is got from disk cache without requesting contract manager
+	httpRequester.GetHandler = nil
+	icon, err = rc.GetTokenIcon(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if icon.Hash != expect.Hash {
+		t.Errorf("expect cached icon %v, actual: %v", expect.Hash, icon.Hash)
+	}
+}
+
+func TestNormalizeTokenIcon(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatal(err)
+	}
+	icon, err := richtypes.NewTokenIcon(buf.Bytes(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rc := &RichClient{}
+	token := cfxaddress.MustNewFromHex("0x8b8689c7f3014a4d86e4d1d0daaf74a47f5e0f27", cfxaddress.NetowrkTypeMainnetID)
+	cases := map[string]string{
+		"":                             "",
+		"https://example.com/usdt.png": "",
+		`data:image/svg+xml,<svg onload="alert(1)"></svg>`: "",
+		icon.DataURI(): icon.DataURI(),
+	}
+	for uri, expect := range cases {
+		if actual := rc.normalizeTokenIcon(token, uri); actual != expect {
+			t.Errorf("expect icon %q of %q, actual: %q", expect, uri, actual)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package walletsdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"sync"
+
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// TokenListRegistry merges token lists in tokenlists.org json schema as the metadata source of tokens.
+//
+// The list with higher priority takes precedence, and the earlier added one takes precedence for the same priority.
+// Name, symbol and decimals of a token come from the list with highest precedence containing it,
+// logoURI is filled by lists with lower precedence if it is empty, and tags are merged.
+type TokenListRegistry struct {
+	mutex   sync.RWMutex
+	entries []tokenListEntry
+	seq     int
+	tokens  map[tokenListKey]*richtypes.TokenListItem
+}
+
+type tokenListEntry struct {
+	list     *richtypes.TokenList
+	priority int
+	seq      int
+}
+
+type tokenListKey struct {
+	chainID uint32
+	address common.Address
+}
+
+// NewTokenListRegistry creates TokenListRegistry without any token list
+func NewTokenListRegistry() *TokenListRegistry {
+	return &TokenListRegistry{tokens: make(map[tokenListKey]*richtypes.TokenListItem)}
+}
+
+// AddTokenList validates and adds token list with priority,
+// the list with same name is replaced if version of the new one is not older, otherwise error is returned.
+func (tlr *TokenListRegistry) AddTokenList(list *richtypes.TokenList, priority int) error {
+	if err := list.Validate(); err != nil {
+		return errors.Wrap(err, "validate token list error")
+	}
+
+	tlr.mutex.Lock()
+	defer tlr.mutex.Unlock()
+
+	for i, entry := range tlr.entries {
+		if entry.list.Name != list.Name {
+			continue
+		}
+		if list.Version.Compare(entry.list.Version) < 0 {
+			return fmt.Errorf("version %v of token list %v is older than the added %v", list.Version, list.Name, entry.list.Version)
+		}
+		tlr.entries = append(tlr.entries[:i], tlr.entries[i+1:]...)
+		break
+	}
+
+	tlr.seq++
+	tlr.entries = append(tlr.entries, tokenListEntry{list: list, priority: priority, seq: tlr.seq})
+	tlr.merge()
+	return nil
+}
+
+// LoadTokenList reads token list from json file and adds it with priority
+func (tlr *TokenListRegistry) LoadTokenList(path string, priority int) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "read token list file %v error", path)
+	}
+
+	var list richtypes.TokenList
+	if err := json.Unmarshal(content, &list); err != nil {
+		return errors.Wrapf(err, "unmarshal token list file %v error", path)
+	}
+	return tlr.AddTokenList(&list, priority)
+}
+
+// RemoveTokenList removes token list by name
+func (tlr *TokenListRegistry) RemoveTokenList(name string) {
+	tlr.mutex.Lock()
+	defer tlr.mutex.Unlock()
+
+	for i, entry := range tlr.entries {
+		if entry.list.Name == name {
+			tlr.entries = append(tlr.entries[:i], tlr.entries[i+1:]...)
+			tlr.merge()
+			return
+		}
+	}
+}
+
+// GetToken returns merged token of chain by hex address, it returns nil if no list contains the token
+func (tlr *TokenListRegistry) GetToken(chainID uint32, address common.Address) *richtypes.TokenListItem {
+	tlr.mutex.RLock()
+	defer tlr.mutex.RUnlock()
+	return tlr.tokens[tokenListKey{chainID, address}]
+}
+
+// GetTokenByAddress returns merged token by base32 address, the chain id is the network id of address
+func (tlr *TokenListRegistry) GetTokenByAddress(address types.Address) *richtypes.TokenListItem {
+	return tlr.GetToken(address.GetNetworkID(), address.MustGetCommonAddress())
+}
+
+// VerifiedTokens returns all tokens of lists, it could be used for TokenReputationChecker.AddVerifiedTokens
+func (tlr *TokenListRegistry) VerifiedTokens() []richtypes.VerifiedToken {
+	tlr.mutex.RLock()
+	defer tlr.mutex.RUnlock()
+
+	verified := make([]richtypes.VerifiedToken, 0, len(tlr.tokens))
+	for key, item := range tlr.tokens {
+		verified = append(verified, richtypes.VerifiedToken{
+			Address: key.address.Hex(),
+			Symbol:  item.Symbol,
+			Name:    item.Name,
+		})
+	}
+	return verified
+}
+
+// merge rebuilds tokens by entries in precedence order, it should be called with lock held
+func (tlr *TokenListRegistry) merge() {
+	sort.SliceStable(tlr.entries, func(i, j int) bool {
+		if tlr.entries[i].priority != tlr.entries[j].priority {
+			return tlr.entries[i].priority > tlr.entries[j].priority
+		}
+		return tlr.entries[i].seq < tlr.entries[j].seq
+	})
+
+	tokens := make(map[tokenListKey]*richtypes.TokenListItem)
+	for _, entry := range tlr.entries {
+		for _, item := range entry.list.Tokens {
+			address, _ := item.CommonAddress()
+			key := tokenListKey{item.ChainID, address}
+
+			merged, ok := tokens[key]
+			if !ok {
+				copied := item
+				copied.Tags = append([]string(nil), item.Tags...)
+				tokens[key] = &copied
+				continue
+			}
+
+			if merged.LogoURI == "" {
+				merged.LogoURI = item.LogoURI
+			}
+			for _, tag := range item.Tags {
+				if !containsString(merged.Tags, tag) {
+					merged.Tags = append(merged.Tags, tag)
+				}
+			}
+		}
+	}
+	tlr.tokens = tokens
+}
+
+func containsString(items []string, target string) bool {
+	for _, item := range items {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
+
+// SetTokenListRegistry sets token lists used as metadata source of tokens ahead of contract manager,
+// token lists are not used if registry is nil.
+func (rc *RichClient) SetTokenListRegistry(registry *TokenListRegistry) {
+	rc.tokenLists = registry
+}
+
+// applyTokenList overrides token metadata except icon by token lists, it returns false if token is not in token lists.
+func (rc *RichClient) applyTokenList(address types.Address, token *richtypes.Token) bool {
+	if rc.tokenLists == nil {
+		return false
+	}
+
+	item := rc.tokenLists.GetTokenByAddress(address)
+	if item == nil {
+		return false
+	}
+
+	token.TokenName = item.Name
+	token.TokenSymbol = item.Symbol
+	token.TokenDecimal = item.Decimals
+	return true
+}
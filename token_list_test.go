@@ -0,0 +1,96 @@
+package walletsdk
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+)
+
+func TestTokenListRegistry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "token_list")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	usdt := cfxaddress.MustNewFromHex("0x8b8689c7f3014a4d86e4d1d0daaf74a47f5e0f27", cfxaddress.NetowrkTypeMainnetID)
+
+	path := filepath.Join(dir, "default.json")
+	content := `{
+		"name": "Default",
+		"timestamp": "2021-06-01T00:00:00Z",
+		"version": {"major": 1, "minor": 0, "patch": 0},
+		"tags": {"stablecoin": {"name": "Stablecoin", "description": "Pegged to USD"}},
+		"tokens": [{
+			"chainId": 1029,
+			"address": "` + usdt.String() + `",
+			"name": "Tether USD",
+			"symbol": "USDT",
+			"decimals": 18,
+			"logoURI": "https://example.com/usdt.png",
+			"tags": ["stablecoin"]
+		}]
+	}`
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := NewTokenListRegistry()
+	if err := registry.LoadTokenList(path, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// the list with higher priority takes precedence and logoURI is filled by the lower one
+	custom := &richtypes.TokenList{
+		Name:      "Custom",
+		Timestamp: "2021-07-01T00:00:00Z",
+		Version:   richtypes.TokenListVersion{Major: 1},
+		Tokens: []richtypes.TokenListItem{{
+			ChainID:  1029,
+			Address:  usdt.MustGetCommonAddress().Hex(),
+			Name:     "USDT on Conflux",
+			Symbol:   "cUSDT",
+			Decimals: 18,
+		}},
+	}
+	if err := registry.AddTokenList(custom, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	item := registry.GetTokenByAddress(usdt)
+	if item == nil || item.Symbol != "cUSDT" || item.LogoURI != "https://example.com/usdt.png" || len(item.Tags) != 1 {
+		t.Fatalf("expect merged token of custom list with logo of default list, actual: %+v", item)
+	}
+
+	older := *custom
+	older.Version = richtypes.TokenListVersion{Minor: 9}
+	if err := registry.AddTokenList(&older, 1); err == nil {
+		t.Errorf("expect error for adding older version of token list")
+	}
+
+	invalid := *custom
+	invalid.Name = "Invalid"
+	invalid.Tokens = []richtypes.TokenListItem{{ChainID: 1, Address: usdt.String(), Name: "Tether USD", Symbol: "USDT", Decimals: 18}}
+	if err := registry.AddTokenList(&invalid, 0); err == nil {
+		t.Errorf("expect error for token whose network id is not chain id")
+	}
+
+	// contract manager is not requested for token in token lists
+	rc := &RichClient{tokenLists: registry}
+	contract, err := rc.GetContractInfo(usdt, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contract.TokenSymbol != "cUSDT" || contract.TokenDecimal != 18 {
+		t.Errorf("expect token info from token lists, actual: %+v", contract.Token)
+	}
+
+	registry.RemoveTokenList("Custom")
+	if item := registry.GetTokenByAddress(usdt); item == nil || item.Symbol != "USDT" {
+		t.Errorf("expect token of default list after removing custom list, actual: %+v", item)
+	}
+}
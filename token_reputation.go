@@ -0,0 +1,294 @@
+package walletsdk
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/resource/contract/abi"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// TokenReputationChecker checks whether tokens are spam or fake by allow list, deny list, verified tokens and heuristics,
+// addresses are keyed by hex address so that the same token of different networks is matched.
+type TokenReputationChecker struct {
+	mutex   sync.RWMutex
+	option  richtypes.TokenReputationOption
+	allowed map[common.Address]struct{}
+	denied  map[common.Address]struct{}
+	// verified maps hex address of verified token to the upper case symbol
+	verified map[common.Address]string
+	// verifiedSymbols contains upper case symbols of verified tokens
+	verifiedSymbols map[string]struct{}
+	// facts caches facts of tokens, the lists are still applied when checking so they could be changed at any time
+	facts map[common.Address]*tokenFacts
+}
+
+// tokenFacts represents information of token got from contract manager and conflux node for checking heuristics
+type tokenFacts struct {
+	hasRecord   bool
+	totalSupply *big.Int
+}
+
+// NewTokenReputationChecker creates TokenReputationChecker, the default option is used if option is nil
+func NewTokenReputationChecker(option *richtypes.TokenReputationOption) *TokenReputationChecker {
+	trc := &TokenReputationChecker{
+		allowed:         make(map[common.Address]struct{}),
+		denied:          make(map[common.Address]struct{}),
+		verified:        make(map[common.Address]string),
+		verifiedSymbols: make(map[string]struct{}),
+		facts:           make(map[common.Address]*tokenFacts),
+	}
+	if option != nil {
+		trc.option = *option
+	}
+	if trc.option.HugeSupply == nil {
+		trc.option.HugeSupply = richtypes.DefaultHugeSupply
+	}
+	return trc
+}
+
+// Allow adds tokens in hex or base32 format to allow list, tokens in allow list are never flagged
+func (trc *TokenReputationChecker) Allow(tokens ...string) error {
+	return trc.addToSet(trc.allowed, tokens)
+}
+
+// Deny adds tokens in hex or base32 format to deny list, tokens in deny list are always flagged
+func (trc *TokenReputationChecker) Deny(tokens ...string) error {
+	return trc.addToSet(trc.denied, tokens)
+}
+
+// AddVerifiedTokens adds verified tokens, other tokens with the same symbol are flagged
+func (trc *TokenReputationChecker) AddVerifiedTokens(tokens ...richtypes.VerifiedToken) error {
+	parsed := make([]common.Address, len(tokens))
+	for i, token := range tokens {
+		address, err := parseFilterAddress(token.Address)
+		if err != nil {
+			return errors.Wrapf(err, "parse address of verified token %v error", token.Symbol)
+		}
+		parsed[i] = address
+	}
+
+	trc.mutex.Lock()
+	defer trc.mutex.Unlock()
+	for i, token := range tokens {
+		symbol := normalizeTokenSymbol(token.Symbol)
+		trc.verified[parsed[i]] = symbol
+		if symbol != "" {
+			trc.verifiedSymbols[symbol] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// LoadVerifiedTokens adds verified tokens from a json file which contains an array of VerifiedToken
+func (trc *TokenReputationChecker) LoadVerifiedTokens(path string) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "read verified token file %v error", path)
+	}
+
+	var tokens []richtypes.VerifiedToken
+	if err := json.Unmarshal(content, &tokens); err != nil {
+		return errors.Wrapf(err, "unmarshal verified token file %v error", path)
+	}
+	return trc.AddVerifiedTokens(tokens...)
+}
+
+// IsHideFlagged returns true if flagged tokens should be removed from results
+func (trc *TokenReputationChecker) IsHideFlagged() bool {
+	return trc.option.HideFlagged
+}
+
+// needFacts returns false if reputation of token is decided without heuristics
+func (trc *TokenReputationChecker) needFacts(address common.Address) bool {
+	trc.mutex.RLock()
+	defer trc.mutex.RUnlock()
+
+	_, isAllowed := trc.allowed[address]
+	_, isDenied := trc.denied[address]
+	_, isVerified := trc.verified[address]
+	return !isAllowed && !isDenied && !isVerified
+}
+
+func (trc *TokenReputationChecker) getFacts(address common.Address) (*tokenFacts, bool) {
+	trc.mutex.RLock()
+	defer trc.mutex.RUnlock()
+	facts, ok := trc.facts[address]
+	return facts, ok
+}
+
+func (trc *TokenReputationChecker) setFacts(address common.Address, facts *tokenFacts) {
+	trc.mutex.Lock()
+	defer trc.mutex.Unlock()
+	trc.facts[address] = facts
+}
+
+// check returns reputation of token by lists and facts, facts is only used when token is not in any list
+func (trc *TokenReputationChecker) check(address common.Address, token *richtypes.Token, facts *tokenFacts) *richtypes.TokenReputation {
+	trc.mutex.RLock()
+	defer trc.mutex.RUnlock()
+
+	if _, ok := trc.denied[address]; ok {
+		return &richtypes.TokenReputation{Flags: []richtypes.TokenFlag{richtypes.TokenFlagDenied}}
+	}
+	if _, ok := trc.allowed[address]; ok {
+		return &richtypes.TokenReputation{Verified: true}
+	}
+	if _, ok := trc.verified[address]; ok {
+		return &richtypes.TokenReputation{Verified: true}
+	}
+
+	reputation := &richtypes.TokenReputation{}
+	if token != nil {
+		if _, ok := trc.verifiedSymbols[normalizeTokenSymbol(token.TokenSymbol)]; ok {
+			reputation.Flags = append(reputation.Flags, richtypes.TokenFlagDuplicateSymbol)
+		}
+	}
+	if facts != nil {
+		if token != nil && token.TokenDecimal == 0 && facts.totalSupply != nil && facts.totalSupply.Cmp(trc.option.HugeSupply) >= 0 {
+			reputation.Flags = append(reputation.Flags, richtypes.TokenFlagHugeSupply)
+		}
+		if !facts.hasRecord {
+			reputation.Flags = append(reputation.Flags, richtypes.TokenFlagNoRecord)
+		}
+	}
+	return reputation
+}
+
+func (trc *TokenReputationChecker) addToSet(set map[common.Address]struct{}, tokens []string) error {
+	parsed := make([]common.Address, len(tokens))
+	for i, token := range tokens {
+		address, err := parseFilterAddress(token)
+		if err != nil {
+			return err
+		}
+		parsed[i] = address
+	}
+
+	trc.mutex.Lock()
+	defer trc.mutex.Unlock()
+	for _, address := range parsed {
+		set[address] = struct{}{}
+	}
+	return nil
+}
+
+// normalizeTokenSymbol returns upper case symbol without spaces for comparing
+func normalizeTokenSymbol(symbol string) string {
+	return strings.ToUpper(strings.TrimSpace(symbol))
+}
+
+// SetTokenReputationChecker sets checker for flagging tokens returned by GetAccountTokens and GetAccountTokenTransfers,
+// tokens are not checked if checker is nil.
+func (rc *RichClient) SetTokenReputationChecker(checker *TokenReputationChecker) {
+	rc.tokenReputation = checker
+}
+
+// getTokenReputation returns reputation of token, it gets facts from contract manager and conflux node when needed
+// and caches them in checker. The heuristics are skipped if failed to get facts, so the token is not flagged by them.
+func (rc *RichClient) getTokenReputation(tokenAddress types.Address, token *richtypes.Token) *richtypes.TokenReputation {
+	address := tokenAddress.MustGetCommonAddress()
+	if !rc.tokenReputation.needFacts(address) {
+		return rc.tokenReputation.check(address, token, nil)
+	}
+
+	facts, ok := rc.tokenReputation.getFacts(address)
+	if !ok {
+		var err error
+		if facts, err = rc.getTokenFacts(tokenAddress, token); err != nil {
+			return rc.tokenReputation.check(address, token, nil)
+		}
+		rc.tokenReputation.setFacts(address, facts)
+	}
+	return rc.tokenReputation.check(address, token, facts)
+}
+
+// getTokenFacts returns facts of token, the token has no record only if contract manager responds it is not found
+func (rc *RichClient) getTokenFacts(tokenAddress types.Address, token *richtypes.Token) (*tokenFacts, error) {
+	facts := &tokenFacts{hasRecord: true}
+	if _, err := rc.GetContractInfo(tokenAddress, false, false); err != nil {
+		if errors.Cause(err) != richtypes.ErrNotFound {
+			return nil, errors.Wrapf(err, "get contract info of %v error", tokenAddress)
+		}
+		facts.hasRecord = false
+	}
+
+	if token != nil && token.TokenDecimal == 0 {
+		totalSupply, err := rc.getTotalSupply(tokenAddress)
+		if err != nil {
+			return nil, err
+		}
+		facts.totalSupply = totalSupply
+	}
+	return facts, nil
+}
+
+// getTotalSupply returns total supply of token by calling the token contract
+func (rc *RichClient) getTotalSupply(tokenAddress types.Address) (*big.Int, error) {
+	contract, err := rc.client.GetContract([]byte(abi.GetABI(richtypes.ERC20)), &tokenAddress)
+	if err != nil {
+		return nil, errors.Wrap(err, "get token contract error")
+	}
+
+	totalSupply := new(big.Int)
+	if err := contract.Call(nil, &totalSupply, "totalSupply"); err != nil {
+		return nil, errors.Wrapf(err, "get total supply of %v error", tokenAddress)
+	}
+	return totalSupply, nil
+}
+
+// flagTokenBalances sets reputation of tokens and removes flagged ones if HideFlagged is set
+func (rc *RichClient) flagTokenBalances(list *richtypes.TokenWithBlanceList) {
+	kept := list.List[:0]
+	for _, tb := range list.List {
+		if tb.Address.MustGetCommonAddress() != (common.Address{}) {
+			tb.Reputation = rc.getTokenReputation(tb.Address, &tb.Token)
+			if tb.Reputation.IsFlagged() && rc.tokenReputation.IsHideFlagged() {
+				continue
+			}
+		}
+		kept = append(kept, tb)
+	}
+	list.List = kept
+}
+
+// flagTokenTransferEvents sets reputation of token transfer events, flagged ones are kept
+// so that paging on scan server results is not affected.
+func (rc *RichClient) flagTokenTransferEvents(list *richtypes.TokenTransferEventList) {
+	reputations := make(map[string]*richtypes.TokenReputation)
+	for i := range list.List {
+		tte := &list.List[i]
+		if tte.ContractAddress == nil {
+			continue
+		}
+		reputation, ok := reputations[tte.ContractAddress.String()]
+		if !ok {
+			reputation = rc.getTokenReputation(*tte.ContractAddress, &tte.Token)
+			reputations[tte.ContractAddress.String()] = reputation
+		}
+		tte.Reputation = reputation
+	}
+}
+
+// isHiddenTokenTransferEvent returns true if the event is flagged and HideFlagged is set.
+func (rc *RichClient) isHiddenTokenTransferEvent(tte *richtypes.TokenTransferEvent) bool {
+	return rc.tokenReputation != nil && rc.tokenReputation.IsHideFlagged() && tte.Reputation.IsFlagged()
+}
+
+// hideFlaggedTokenTransferEvents removes flagged token transfer events if HideFlagged is set,
+// the Total is not changed as it is counted by scan server.
+func (rc *RichClient) hideFlaggedTokenTransferEvents(list *richtypes.TokenTransferEventList) {
+	kept := list.List[:0]
+	for i := range list.List {
+		if !rc.isHiddenTokenTransferEvent(&list.List[i]) {
+			kept = append(kept, list.List[i])
+		}
+	}
+	list.List = kept
+}
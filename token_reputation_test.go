@@ -0,0 +1,126 @@
+package walletsdk
+
+import (
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+)
+
+func TestTokenReputationChecker(t *testing.T) {
+	dir, err := ioutil.TempDir("", "token_reputation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	usdt := cfxaddress.MustNewFromHex("0x8b8689c7f3014a4d86e4d1d0daaf74a47f5e0f27", cfxaddress.NetowrkTypeMainnetID)
+	fake := cfxaddress.MustNewFromHex("0x8d545118d91c027c805c552f63a5c00a20ae6aca", cfxaddress.NetowrkTypeMainnetID)
+	denied := cfxaddress.MustNewFromHex("0x8d7df9316faa0586e175b5e6d03c6bda76e3d950", cfxaddress.NetowrkTypeMainnetID)
+
+	path := filepath.Join(dir, "verified.json")
+	content := `[{"address":"` + usdt.String() + `","symbol":"USDT","name":"Tether USD"}]`
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	checker := NewTokenReputationChecker(&richtypes.TokenReputationOption{HideFlagged: true})
+	if err := checker.LoadVerifiedTokens(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := checker.Deny(denied.MustGetCommonAddress().Hex()); err != nil {
+		t.Fatal(err)
+	}
+
+	reputation := checker.check(usdt.MustGetCommonAddress(), &richtypes.Token{TokenSymbol: "USDT", TokenDecimal: 18}, nil)
+	if !reputation.Verified || reputation.IsFlagged() {
+		t.Errorf("expect verified token without flags, actual: %+v", reputation)
+	}
+
+	facts := &tokenFacts{totalSupply: new(big.Int).Mul(richtypes.DefaultHugeSupply, big.NewInt(10))}
+	reputation = checker.check(fake.MustGetCommonAddress(), &richtypes.Token{TokenSymbol: " usdt", TokenDecimal: 0}, facts)
+	expect := []richtypes.TokenFlag{richtypes.TokenFlagDuplicateSymbol, richtypes.TokenFlagHugeSupply, richtypes.TokenFlagNoRecord}
+	if reputation.Verified || len(reputation.Flags) != len(expect) {
+		t.Fatalf("expect flags %v, actual: %+v", expect, reputation)
+	}
+	for i := range expect {
+		if reputation.Flags[i] != expect[i] {
+			t.Errorf("expect flags %v, actual: %v", expect, reputation.Flags)
+		}
+	}
+
+	if err := checker.Allow(fake.String()); err != nil {
+		t.Fatal(err)
+	}
+	if checker.needFacts(fake.MustGetCommonAddress()) {
+		t.Errorf("expect allowed token %v not need facts", fake)
+	}
+
+	// the denied token is hidden
+	rc := &RichClient{tokenReputation: checker}
+	list := &richtypes.TokenTransferEventList{
+		Total: 2,
+		List: []richtypes.TokenTransferEvent{
+			{Token: richtypes.Token{TokenSymbol: "USDT"}, ContractAddress: &usdt},
+			{Token: richtypes.Token{TokenSymbol: "WCFX"}, ContractAddress: &denied},
+		},
+	}
+	rc.flagTokenTransferEvents(list)
+	if len(list.List) != 2 || !list.List[1].Reputation.IsFlagged() {
+		t.Errorf("expect flagged transfer event kept before hiding, actual: %+v", list.List)
+	}
+	rc.hideFlaggedTokenTransferEvents(list)
+	if len(list.List) != 1 || list.List[0].ContractAddress.String() != usdt.String() || !list.List[0].Reputation.Verified {
+		t.Errorf("expect only verified transfer event left, actual: %+v", list.List)
+	}
+}
+
+// statusRequester responds empty json with status code, or returns err if it is not nil
+type statusRequester struct {
+	status int
+	err    error
+	calls  int
+}
+
+func (r *statusRequester) Get(url string) (*http.Response, error) {
+	r.calls++
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &http.Response{StatusCode: r.status, Body: ioutil.NopCloser(strings.NewReader("{}"))}, nil
+}
+
+func TestTokenReputationNoRecord(t *testing.T) {
+	token := cfxaddress.MustNewFromHex("0x8a8689c7f3014a4d86e4d1d0daaf74a47f5e0f27", cfxaddress.NetowrkTypeMainnetID)
+	requester := &statusRequester{err: errors.New("connection refused")}
+	rc := &RichClient{
+		contractManager: &scanServer{Scheme: "http", Address: "test", HTTPRequester: requester},
+		tokenReputation: NewTokenReputationChecker(nil),
+	}
+
+	// the token is not flagged when contract manager is unavailable
+	reputation := rc.getTokenReputation(token, &richtypes.Token{TokenSymbol: "ABC", TokenDecimal: 18})
+	if reputation.IsFlagged() {
+		t.Errorf("expect token not flagged on transport error, actual: %+v", reputation)
+	}
+
+	requester.err, requester.status = nil, http.StatusNotFound
+	reputation = rc.getTokenReputation(token, &richtypes.Token{TokenSymbol: "ABC", TokenDecimal: 18})
+	if len(reputation.Flags) != 1 || reputation.Flags[0] != richtypes.TokenFlagNoRecord {
+		t.Errorf("expect no record flag, actual: %+v", reputation)
+	}
+
+	// the facts are cached
+	calls := requester.calls
+	rc.getTokenReputation(token, &richtypes.Token{TokenSymbol: "ABC", TokenDecimal: 18})
+	if requester.calls != calls {
+		t.Errorf("expect facts cached, actual requests: %v", requester.calls-calls)
+	}
+}
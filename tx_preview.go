@@ -0,0 +1,169 @@
+package walletsdk
+
+import (
+	"fmt"
+	"math/big"
+
+	richconstants "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/constants"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/decoder"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/helper"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/resource/contract/abi"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// approvals not less than unlimitedApprovalThreshold are regarded as unlimited,
+// because it is far beyond total supply of any real token.
+var unlimitedApprovalThreshold = new(big.Int).Lsh(big.NewInt(1), 128)
+
+// PreviewUnsignedTransaction creates a human readable preview of what the unsigned transaction will do,
+// it contains the decoded method call, formatted transfers, max fee and risk warnings.
+//
+// The ABI of contract is acquired by GetContractInfo if the converter is created with rich client,
+// otherwise the ABIs in registry are used.
+func (tc *TxDictConverter) PreviewUnsignedTransaction(tx *types.UnsignedTransaction) (*richtypes.TxPreview, error) {
+	if tx == nil {
+		return nil, fmt.Errorf("tx is nil")
+	}
+
+	preview := new(richtypes.TxPreview)
+	preview.TxDictBase = *tc.ConvertByUnsignedTransaction(tx)
+
+	if tx.Gas != nil && tx.GasPrice != nil {
+		preview.MaxGasFee = new(big.Int).Mul(tx.Gas.ToInt(), tx.GasPrice.ToInt())
+	}
+
+	if tx.StorageLimit != nil {
+		preview.StorageCollateral = new(big.Int).Mul(
+			new(big.Int).SetUint64(uint64(*tx.StorageLimit)),
+			big.NewInt(richconstants.CollateralDripPerStorageByte))
+	}
+
+	// inputs and outputs are paired by index, the unpaired units are ignored
+	pairs := len(preview.Inputs)
+	if len(preview.Outputs) < pairs {
+		pairs = len(preview.Outputs)
+	}
+	preview.Transfers = make([]richtypes.TransferPreview, 0, pairs)
+	for i := 0; i < pairs; i++ {
+		input, output := preview.Inputs[i], preview.Outputs[i]
+		// skip zero cfx transfer of contract call
+		if input.TokenIdentifier == nil && (input.Value == nil || input.Value.Sign() == 0) && len(tx.Data) > 0 {
+			continue
+		}
+
+		amount := input.FormattedValue()
+		if input.TokenId != nil {
+			amount = input.Value.String()
+		}
+		preview.Transfers = append(preview.Transfers, richtypes.TransferPreview{
+			From:            input.Address,
+			To:              output.Address,
+			Amount:          amount,
+			TokenCode:       input.TokenCode,
+			TokenIdentifier: input.TokenIdentifier,
+			TokenId:         input.TokenId,
+		})
+	}
+
+	if tx.To != nil && len(tx.Data) >= 4 {
+		abiJSON := ""
+		if contractType, ok := decoder.GetInternalContractType(tx.To); ok {
+			abiJSON = abi.GetABI(contractType)
+		} else if tc.richClient != nil && tx.To.GetAddressType() == cfxaddress.AddressTypeContract {
+			if contract, err := tc.richClient.GetContractInfo(*tx.To, true, false); err == nil {
+				abiJSON = contract.ABI
+			}
+		}
+
+		// the method is unknown if decode failed, the preview is still useful without it
+		if methodCall, err := tc.decoder.DecodeMethodCall(tx.Data, abiJSON); err == nil {
+			tc.formatMethodArgs(methodCall)
+			preview.Method = methodCall
+		}
+	}
+
+	preview.Warnings = tc.getPreviewWarnings(tx, preview)
+	return preview, nil
+}
+
+// formatMethodArgs converts address arguments to base32 address
+func (tc *TxDictConverter) formatMethodArgs(methodCall *richtypes.MethodCall) {
+	for i, arg := range methodCall.Args {
+		if commonAddress, ok := arg.Value.(common.Address); ok {
+			methodCall.Args[i].Value = helper.MustNewCfxAddressPtr(&commonAddress, tc.networkID)
+		}
+	}
+}
+
+func (tc *TxDictConverter) getPreviewWarnings(tx *types.UnsignedTransaction, preview *richtypes.TxPreview) []richtypes.TxWarning {
+	warnings := make([]richtypes.TxWarning, 0)
+
+	if label, privileged := tc.getInternalContractLabel(tx); privileged {
+		warnings = append(warnings, richtypes.TxWarning{
+			Type:    richtypes.PrivilegedActionWarning,
+			Message: label,
+		})
+	}
+
+	method := preview.Method
+	if method != nil {
+		switch method.Signature {
+		case "approve(address,uint256)":
+			if amount, ok := method.Args[1].Value.(*big.Int); ok && amount.Cmp(unlimitedApprovalThreshold) >= 0 {
+				warnings = append(warnings, richtypes.TxWarning{
+					Type:    richtypes.UnlimitedApprovalWarning,
+					Message: fmt.Sprintf("approve %v to transfer unlimited tokens of %v", method.Args[0].Value, tx.To),
+				})
+			}
+		case "setApprovalForAll(address,bool)":
+			if approved, ok := method.Args[1].Value.(bool); ok && approved {
+				warnings = append(warnings, richtypes.TxWarning{
+					Type:    richtypes.UnlimitedApprovalWarning,
+					Message: fmt.Sprintf("approve %v to transfer all tokens of %v", method.Args[0].Value, tx.To),
+				})
+			}
+		}
+
+		if !method.Payable && tx.Value != nil && tx.Value.ToInt().Sign() > 0 {
+			warnings = append(warnings, richtypes.TxWarning{
+				Type:    richtypes.NonPayableValueWarning,
+				Message: fmt.Sprintf("send %v CFX to non-payable function %v", richtypes.FormatDripToCFX(tx.Value.ToInt()), method.Signature),
+			})
+		}
+	}
+
+	// receivers of cfx or tokens which are contracts but not tokens maybe couldn't transfer them out
+	if tc.richClient != nil {
+		for _, transfer := range preview.Transfers {
+			if transfer.To == nil || transfer.To.GetAddressType() != cfxaddress.AddressTypeContract {
+				continue
+			}
+			// cfx sent along with contract call is expected by the contract
+			if transfer.TokenIdentifier == nil && len(tx.Data) > 0 {
+				continue
+			}
+			if !tc.isTokenContract(*transfer.To) {
+				warnings = append(warnings, richtypes.TxWarning{
+					Type:    richtypes.NonTokenContractWarning,
+					Message: fmt.Sprintf("send %v %v to contract %v which is not a token", transfer.Amount, transfer.TokenCode, transfer.To),
+				})
+			}
+		}
+	}
+
+	if len(warnings) == 0 {
+		return nil
+	}
+	return warnings
+}
+
+func (tc *TxDictConverter) isTokenContract(address types.Address) bool {
+	contract, err := tc.richClient.GetContractInfo(address, false, false)
+	if err != nil {
+		return false
+	}
+	return contract.TokenSymbol != ""
+}
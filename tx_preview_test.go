@@ -0,0 +1,161 @@
+package walletsdk
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	sdk "github.com/Conflux-Chain/go-conflux-sdk"
+	richconstants "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/constants"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/resource/contract/abi"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/constants"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestPreviewUnsignedTransaction(t *testing.T) {
+	converter, err := NewTxDictConverter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	erc20, err := sdk.NewContract([]byte(abi.GetABI(richtypes.ERC20)), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+	token := cfxaddress.MustNewFromHex("0x8e2f2e68eb75bb8b18caafe9607242d4748f8d98", cfxaddress.NetowrkTypeMainnetID)
+	spender := cfxaddress.MustNewFromHex("0x160ebef20c1f739957bf9eecd040bce699cc42c6", cfxaddress.NetowrkTypeMainnetID)
+
+	data, err := erc20.GetData("approve", spender.MustGetCommonAddress(), constants.MaxUint256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := new(types.UnsignedTransaction)
+	tx.From = &from
+	tx.To = &token
+	tx.Value = types.NewBigInt(1000000000000000000)
+	tx.Gas = types.NewBigInt(100000)
+	tx.GasPrice = types.NewBigInt(1000000000)
+	tx.StorageLimit = types.NewUint64(1024)
+	tx.Data = data
+
+	preview, err := converter.PreviewUnsignedTransaction(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if preview.Method == nil || preview.Method.Name != "approve" {
+		t.Fatalf("expect method approve, actual: %+v", preview.Method)
+	}
+
+	if preview.MaxGasFee.Cmp(big.NewInt(100000000000000)) != 0 {
+		t.Errorf("expect max gas fee 100000000000000, actual: %v", preview.MaxGasFee)
+	}
+
+	if preview.StorageCollateral.Cmp(big.NewInt(1000000000000000000)) != 0 {
+		t.Errorf("expect storage collateral 1000000000000000000, actual: %v", preview.StorageCollateral)
+	}
+
+	warningTypes := make(map[richtypes.TxWarningType]bool)
+	for _, warning := range preview.Warnings {
+		warningTypes[warning.Type] = true
+	}
+	if !warningTypes[richtypes.UnlimitedApprovalWarning] || !warningTypes[richtypes.NonPayableValueWarning] {
+		t.Errorf("expect unlimited approval and non-payable value warnings, actual: %+v", preview.Warnings)
+	}
+}
+
+func TestPreviewInternalContractCall(t *testing.T) {
+	converter, err := NewTxDictConverter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sponsorControl, err := sdk.NewContract([]byte(abi.GetABI(richtypes.SPONSOR)), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adminControl, err := sdk.NewContract([]byte(abi.GetABI(richtypes.ADMIN)), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+	contract := cfxaddress.MustNewFromHex("0x8e2f2e68eb75bb8b18caafe9607242d4748f8d98", cfxaddress.NetowrkTypeMainnetID)
+	sponsorControlAddress := cfxaddress.MustNewFromCommon(richconstants.SponsorWhitelistControlHexAddress, cfxaddress.NetowrkTypeMainnetID)
+	adminControlAddress := cfxaddress.MustNewFromCommon(richconstants.AdminControlHexAddress, cfxaddress.NetowrkTypeMainnetID)
+
+	data, err := sponsorControl.GetData("setSponsorForGas", contract.MustGetCommonAddress(), big.NewInt(1e15))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := new(types.UnsignedTransaction)
+	tx.From = &from
+	tx.To = &sponsorControlAddress
+	tx.Value = types.NewBigInt(1e18)
+	tx.Data = data
+
+	preview, err := converter.PreviewUnsignedTransaction(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectLabel := fmt.Sprintf("sponsor gas of contract %v with 1 CFX, upper bound 0.001 CFX per transaction", contract)
+	if preview.Extra.Label != expectLabel {
+		t.Errorf("expect label %v, actual: %v", expectLabel, preview.Extra.Label)
+	}
+	if len(preview.Transfers) != 1 || preview.Transfers[0].Amount != "1" {
+		t.Errorf("expect transfer of 1 CFX to SponsorWhitelistControl, actual: %+v", preview.Transfers)
+	}
+	if preview.Method == nil || preview.Method.Name != "setSponsorForGas" || len(preview.Warnings) != 0 {
+		t.Errorf("expect method setSponsorForGas without warning, actual: %+v, %+v", preview.Method, preview.Warnings)
+	}
+
+	data, err = adminControl.GetData("destroy", contract.MustGetCommonAddress())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.To = &adminControlAddress
+	tx.Value = types.NewBigInt(0)
+	tx.Data = data
+
+	preview, err = converter.PreviewUnsignedTransaction(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(preview.Warnings) != 1 || preview.Warnings[0].Type != richtypes.PrivilegedActionWarning {
+		t.Errorf("expect privileged action warning, actual: %+v", preview.Warnings)
+	}
+
+	// the zero address renounces admin, but means all users in sponsor whitelist
+	for _, c := range []struct {
+		contract *sdk.Contract
+		to       types.Address
+		method   string
+		args     []interface{}
+		label    string
+	}{
+		{adminControl, adminControlAddress, "setAdmin", []interface{}{contract.MustGetCommonAddress(), common.Address{}},
+			fmt.Sprintf("transfer admin of contract %v to nobody (admin removed)", contract)},
+		{sponsorControl, sponsorControlAddress, "addPrivilegeByAdmin", []interface{}{contract.MustGetCommonAddress(), []common.Address{{}}},
+			fmt.Sprintf("add [all users] to sponsor whitelist of contract %v", contract)},
+	} {
+		if tx.Data, err = c.contract.GetData(c.method, c.args...); err != nil {
+			t.Fatal(err)
+		}
+		to := c.to
+		tx.To = &to
+		if preview, err = converter.PreviewUnsignedTransaction(tx); err != nil {
+			t.Fatal(err)
+		}
+		if preview.Extra.Label != c.label {
+			t.Errorf("expect label %v, actual: %v", c.label, preview.Extra.Label)
+		}
+	}
+}
@@ -0,0 +1,336 @@
+package walletsdk
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/rpc"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/pkg/errors"
+)
+
+// default values of TxDictStreamOption
+var (
+	defaultStreamPollInterval = time.Second
+	defaultStreamReorgDepth   = uint64(100)
+)
+
+var errStreamStopped = errors.New("stream is stopped")
+
+// epochsSubscriber is implemented by sdk.Client created with websocket url
+type epochsSubscriber interface {
+	SubscribeEpochs(channel chan types.WebsocketEpochResponse) (*rpc.ClientSubscription, error)
+}
+
+// TxDictsHandler handles TxDicts of an epoch emitted by TxDictStream, the checkpoint is saved only after it returns nil.
+// The epoch is emitted again if it returns error or the checkpoint is failed to save, so it should be idempotent.
+type TxDictsHandler func(epochTxDicts richtypes.EpochTxDicts) error
+
+// TxDictStreamOption is the option of TxDictStream, the zero value fields will use default values
+type TxDictStreamOption struct {
+	// StartEpoch is the first epoch to process if there is no checkpoint in store
+	StartEpoch uint64
+	// FollowEpoch is the epoch to follow, it could be EpochLatestState or EpochLatestConfirmed, default is EpochLatestConfirmed
+	FollowEpoch *types.Epoch
+	// Subscribe means using websocket subscription of epochs to be notified instead of polling, the client should be websocket client
+	Subscribe bool
+	// PollInterval is the interval of polling new epochs, default is 1 second
+	PollInterval time.Duration
+	// ReorgDepth is the number of recent pivot hashes kept in checkpoint for detecting reorg, default is 100
+	ReorgDepth uint64
+	// Filter limits TxDicts to transactions moving CFX or tokens of it from or to its addresses
+	Filter *AddressFilter
+	// Store persists the checkpoint, default is a MemoryCheckpointStore
+	Store CheckpointStore
+	// OnError is called when error occurs in syncing started by Start(),
+	// syncing is stopped on subscription error and could be restarted by Start()
+	OnError func(err error)
+}
+
+// TxDictStream emits TxDicts epoch by epoch in order from a checkpointed epoch to handler,
+// and emits reverted events for epochs dropped by pivot chain reorg.
+// Every epoch is delivered at least once, because the checkpoint is saved after handler returns.
+type TxDictStream struct {
+	rc      *RichClient
+	option  TxDictStreamOption
+	handler TxDictsHandler
+
+	// syncMutex makes sure only one Sync is running
+	syncMutex  sync.Mutex
+	checkpoint *StreamCheckpoint
+	next       uint64
+
+	mutex sync.Mutex
+	stop  chan struct{}
+}
+
+// NewTxDictStream creates a TxDictStream and loads checkpoint from store, option could be nil to use default values
+func (rc *RichClient) NewTxDictStream(handler TxDictsHandler, option *TxDictStreamOption) (*TxDictStream, error) {
+	if handler == nil {
+		return nil, errors.New("handler is nil")
+	}
+
+	s := &TxDictStream{rc: rc, handler: handler}
+
+	if option != nil {
+		s.option = *option
+	}
+	if s.option.FollowEpoch == nil {
+		s.option.FollowEpoch = types.EpochLatestConfirmed
+	}
+	if s.option.PollInterval <= 0 {
+		s.option.PollInterval = defaultStreamPollInterval
+	}
+	if s.option.ReorgDepth == 0 {
+		s.option.ReorgDepth = defaultStreamReorgDepth
+	}
+	if s.option.Store == nil {
+		s.option.Store = &MemoryCheckpointStore{}
+	}
+
+	checkpoint, err := s.option.Store.LoadCheckpoint()
+	if err != nil {
+		return nil, errors.Wrap(err, "load checkpoint error")
+	}
+
+	if checkpoint == nil {
+		s.checkpoint = &StreamCheckpoint{PivotHashes: make(map[uint64]types.Hash)}
+		s.next = s.option.StartEpoch
+	} else {
+		s.checkpoint = checkpoint
+		if s.checkpoint.PivotHashes == nil {
+			s.checkpoint.PivotHashes = make(map[uint64]types.Hash)
+		}
+		s.next = checkpoint.EpochNumber + 1
+	}
+
+	return s, nil
+}
+
+// NextEpoch returns the next epoch to process
+func (s *TxDictStream) NextEpoch() uint64 {
+	s.syncMutex.Lock()
+	defer s.syncMutex.Unlock()
+	return s.next
+}
+
+// Start starts syncing in a new goroutine by polling or websocket subscription
+func (s *TxDictStream) Start() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stop != nil {
+		return nil
+	}
+
+	var notify <-chan types.WebsocketEpochResponse
+	var subErr <-chan error
+	var ticker *time.Ticker
+	if s.option.Subscribe {
+		subscriber, ok := s.rc.client.(epochsSubscriber)
+		if !ok {
+			return errors.New("client does not support subscribing epochs")
+		}
+
+		channel := make(chan types.WebsocketEpochResponse, 100)
+		sub, err := subscriber.SubscribeEpochs(channel)
+		if err != nil {
+			return errors.Wrap(err, "subscribe epochs error")
+		}
+		notify, subErr = channel, sub.Err()
+
+		stop := make(chan struct{})
+		go func() {
+			<-stop
+			sub.Unsubscribe()
+		}()
+		s.stop = stop
+	} else {
+		ticker = time.NewTicker(s.option.PollInterval)
+		s.stop = make(chan struct{})
+	}
+
+	go func(stop chan struct{}) {
+		var tick <-chan time.Time
+		if ticker != nil {
+			defer ticker.Stop()
+			tick = ticker.C
+		}
+
+		for {
+			select {
+			case <-stop:
+				return
+			case err := <-subErr:
+				// release the dead subscription so that Start could subscribe again
+				s.mutex.Lock()
+				if s.stop == stop {
+					close(stop)
+					s.stop = nil
+				}
+				s.mutex.Unlock()
+				s.handleError(errors.Wrap(err, "epochs subscription error"))
+				return
+			case <-notify:
+			case <-tick:
+			}
+
+			if err := s.sync(stop); err != nil && errors.Cause(err) != errStreamStopped {
+				s.handleError(err)
+			}
+		}
+	}(s.stop)
+
+	return nil
+}
+
+// Stop stops syncing started by Start
+func (s *TxDictStream) Stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+}
+
+// Sync processes epochs from the next epoch to the followed epoch, the handler is called synchronously
+func (s *TxDictStream) Sync() error {
+	return s.sync(nil)
+}
+
+func (s *TxDictStream) sync(stop chan struct{}) error {
+	s.syncMutex.Lock()
+	defer s.syncMutex.Unlock()
+
+	target, err := s.rc.client.GetEpochNumber(s.option.FollowEpoch)
+	if err != nil {
+		return errors.Wrapf(err, "get epoch number of %v error", s.option.FollowEpoch)
+	}
+
+	for s.next <= target.ToInt().Uint64() {
+		if err := s.processEpoch(s.next, stop); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processEpoch emits TxDicts of epoch, or rewinds and emits reverted events if the pivot chain is reorganized
+func (s *TxDictStream) processEpoch(epoch uint64, stop chan struct{}) error {
+	pivotHash, blockhashes, err := s.getPivotHash(epoch)
+	if err != nil {
+		return err
+	}
+
+	if prevPivotHash, ok := s.checkpoint.PivotHashes[epoch-1]; ok && epoch > 0 {
+		pivot, err := s.rc.client.GetBlockSummaryByHash(pivotHash)
+		if err != nil {
+			return errors.Wrapf(err, "get block summary by hash %v error", pivotHash)
+		}
+		if pivot == nil {
+			return fmt.Errorf("pivot block %v of epoch %v not found", pivotHash, epoch)
+		}
+		if pivot.ParentHash != prevPivotHash {
+			return s.rewind(stop)
+		}
+	}
+
+	txDicts, err := s.rc.getTxDictsByBlockhashes(blockhashes, s.option.Filter)
+	if err != nil {
+		return errors.Wrapf(err, "get tx dicts of epoch %v error", epoch)
+	}
+
+	if err := s.emit(richtypes.EpochTxDicts{EpochNumber: epoch, PivotHash: pivotHash, TxDicts: txDicts}, stop); err != nil {
+		return err
+	}
+
+	s.checkpoint.PivotHashes[epoch] = pivotHash
+	if epoch >= s.option.ReorgDepth {
+		for e := range s.checkpoint.PivotHashes {
+			if e <= epoch-s.option.ReorgDepth {
+				delete(s.checkpoint.PivotHashes, e)
+			}
+		}
+	}
+	return s.saveCheckpoint(epoch + 1)
+}
+
+// rewind emits reverted events from the last processed epoch back to the epoch whose pivot hash is not changed
+func (s *TxDictStream) rewind(stop chan struct{}) error {
+	next := s.next
+	for next > 0 {
+		epoch := next - 1
+		storedHash, ok := s.checkpoint.PivotHashes[epoch]
+		if !ok {
+			break
+		}
+
+		pivotHash, _, err := s.getPivotHash(epoch)
+		if err != nil {
+			return err
+		}
+		if pivotHash == storedHash {
+			break
+		}
+
+		if err := s.emit(richtypes.EpochTxDicts{EpochNumber: epoch, PivotHash: storedHash, Reverted: true}, stop); err != nil {
+			return err
+		}
+		delete(s.checkpoint.PivotHashes, epoch)
+		if err := s.saveCheckpoint(epoch); err != nil {
+			// keep the pivot hash to emit reverted event again
+			s.checkpoint.PivotHashes[epoch] = storedHash
+			return err
+		}
+		next = epoch
+	}
+	return nil
+}
+
+func (s *TxDictStream) getPivotHash(epoch uint64) (types.Hash, []types.Hash, error) {
+	blockhashes, err := s.rc.client.GetBlocksByEpoch(types.NewEpochNumberUint64(epoch))
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "get blocks by epoch %v error", epoch)
+	}
+	if len(blockhashes) == 0 {
+		return "", nil, fmt.Errorf("no blocks in epoch %v", epoch)
+	}
+	// the pivot block is the last one of epoch
+	return blockhashes[len(blockhashes)-1], blockhashes, nil
+}
+
+// saveCheckpoint saves checkpoint with next epoch, the next epoch is not changed if failed to save
+func (s *TxDictStream) saveCheckpoint(next uint64) error {
+	if next > 0 {
+		s.checkpoint.EpochNumber = next - 1
+	}
+	if err := s.option.Store.SaveCheckpoint(s.checkpoint); err != nil {
+		return errors.Wrap(err, "save checkpoint error")
+	}
+	s.next = next
+	return nil
+}
+
+// emit passes event to handler unless the stream is stopped
+func (s *TxDictStream) emit(event richtypes.EpochTxDicts, stop chan struct{}) error {
+	select {
+	case <-stop:
+		return errStreamStopped
+	default:
+	}
+
+	if err := s.handler(event); err != nil {
+		return errors.Wrapf(err, "handle TxDicts of epoch %v error", event.EpochNumber)
+	}
+	return nil
+}
+
+func (s *TxDictStream) handleError(err error) {
+	if s.option.OnError != nil {
+		s.option.OnError(err)
+	}
+}
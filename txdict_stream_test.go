@@ -0,0 +1,190 @@
+package walletsdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	sdk "github.com/Conflux-Chain/go-conflux-sdk"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/rpc"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// streamClientMock mocks a pivot chain with one empty block per epoch
+type streamClientMock struct {
+	sdk.ClientOperator
+	latest       uint64
+	missingBlock bool
+	riskErr      error
+}
+
+func streamPivotHash(epoch uint64) types.Hash {
+	return types.Hash(fmt.Sprintf("0x%064x", epoch))
+}
+
+func (c *streamClientMock) GetEpochNumber(epoch ...*types.Epoch) (*hexutil.Big, error) {
+	return types.NewBigInt(c.latest), nil
+}
+
+func (c *streamClientMock) GetBlocksByEpoch(epoch *types.Epoch) ([]types.Hash, error) {
+	number, _ := epoch.ToInt()
+	return []types.Hash{streamPivotHash(number.Uint64())}, nil
+}
+
+func (c *streamClientMock) GetBlockSummaryByHash(blockHash types.Hash) (*types.BlockSummary, error) {
+	number, _ := new(big.Int).SetString(string(blockHash)[2:], 16)
+	summary := &types.BlockSummary{}
+	summary.ParentHash = streamPivotHash(number.Uint64() - 1)
+	return summary, nil
+}
+
+func (c *streamClientMock) GetBlockByHash(blockHash types.Hash) (*types.Block, error) {
+	if c.missingBlock {
+		return nil, nil
+	}
+	return &types.Block{}, nil
+}
+
+func (c *streamClientMock) GetBlockConfirmationRisk(blockHash types.Hash) (*big.Float, error) {
+	if c.riskErr != nil {
+		return nil, c.riskErr
+	}
+	return big.NewFloat(0), nil
+}
+
+func (c *streamClientMock) GetNetworkID() (uint32, error) {
+	return 1029, nil
+}
+
+func TestTxDictStreamRedeliverOnHandlerError(t *testing.T) {
+	rc := &RichClient{client: &streamClientMock{latest: 3}}
+	store := &MemoryCheckpointStore{}
+
+	var handled []uint64
+	failed := false
+	stream, err := rc.NewTxDictStream(func(epochTxDicts richtypes.EpochTxDicts) error {
+		if epochTxDicts.EpochNumber == 2 && !failed {
+			failed = true
+			return errors.New("consumer error")
+		}
+		handled = append(handled, epochTxDicts.EpochNumber)
+		return nil
+	}, &TxDictStreamOption{StartEpoch: 1, Store: store})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := stream.Sync(); err == nil {
+		t.Fatalf("expect handler error")
+	}
+	checkpoint, _ := store.LoadCheckpoint()
+	if stream.NextEpoch() != 2 || checkpoint.EpochNumber != 1 {
+		t.Fatalf("expect checkpoint not saved for epoch 2, next: %v, checkpoint: %+v", stream.NextEpoch(), checkpoint)
+	}
+
+	if err := stream.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(handled, []uint64{1, 2, 3}) || stream.NextEpoch() != 4 {
+		t.Fatalf("expect epochs 1 to 3 handled, actual: %v, next: %v", handled, stream.NextEpoch())
+	}
+}
+
+func TestTxDictStreamBlockErrors(t *testing.T) {
+	client := &streamClientMock{latest: 3, riskErr: errors.New("rpc error")}
+	rc := &RichClient{client: client}
+	stream, err := rc.NewTxDictStream(func(epochTxDicts richtypes.EpochTxDicts) error {
+		return nil
+	}, &TxDictStreamOption{StartEpoch: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := stream.Sync(); err == nil || stream.NextEpoch() != 1 {
+		t.Fatalf("expect error of getting revert rate and epoch 1 not processed, next: %v", stream.NextEpoch())
+	}
+
+	client.riskErr = nil
+	client.missingBlock = true
+	if err := stream.Sync(); err == nil || stream.NextEpoch() != 1 {
+		t.Fatalf("expect error of missing block and epoch 1 not processed, next: %v", stream.NextEpoch())
+	}
+
+	client.missingBlock = false
+	if err := stream.Sync(); err != nil || stream.NextEpoch() != 4 {
+		t.Fatalf("expect epochs 1 to 3 processed, error: %v, next: %v", err, stream.NextEpoch())
+	}
+}
+
+// epochsService serves epochs subscription without notification
+type epochsService struct{}
+
+func (s *epochsService) Epochs(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, ok := rpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	return notifier.CreateSubscription(), nil
+}
+
+// subscribingStreamClientMock mocks a websocket client, every subscription is served by a new in process rpc client
+type subscribingStreamClientMock struct {
+	streamClientMock
+	mutex   sync.Mutex
+	clients []*rpc.Client
+}
+
+func (c *subscribingStreamClientMock) SubscribeEpochs(channel chan types.WebsocketEpochResponse) (*rpc.ClientSubscription, error) {
+	server := rpc.NewServer()
+	if err := server.RegisterName("cfx", &epochsService{}); err != nil {
+		return nil, err
+	}
+	client := rpc.DialInProc(server)
+
+	c.mutex.Lock()
+	c.clients = append(c.clients, client)
+	c.mutex.Unlock()
+	return client.Subscribe(context.Background(), "cfx", channel, "epochs")
+}
+
+func TestTxDictStreamRestartAfterSubscriptionError(t *testing.T) {
+	client := &subscribingStreamClientMock{streamClientMock: streamClientMock{latest: 1}}
+	rc := &RichClient{client: client}
+
+	errs := make(chan error, 1)
+	stream, err := rc.NewTxDictStream(func(epochTxDicts richtypes.EpochTxDicts) error {
+		return nil
+	}, &TxDictStreamOption{Subscribe: true, OnError: func(err error) { errs <- err }})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := stream.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Stop()
+
+	// the subscription fails when the connection is closed
+	client.clients[0].Close()
+	select {
+	case <-errs:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expect subscription error")
+	}
+
+	if err := stream.Start(); err != nil {
+		t.Fatal(err)
+	}
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if len(client.clients) != 2 {
+		t.Fatalf("expect subscribing again after subscription error, subscriptions: %v", len(client.clients))
+	}
+}
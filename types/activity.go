@@ -0,0 +1,48 @@
+package richtypes
+
+import (
+	"time"
+
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+)
+
+// TransferType represents type of transfer list of scan server
+type TransferType string
+
+const (
+	// TransferTypeCFX represents transactions of main coin
+	TransferTypeCFX     TransferType = "CFX"
+	TransferTypeERC20   TransferType = "ERC20"
+	TransferTypeERC777  TransferType = "ERC777"
+	TransferTypeERC721  TransferType = "ERC721"
+	TransferTypeERC1155 TransferType = "ERC1155"
+)
+
+// AllTransferTypes contains all transfer types in the order of items with the same position in activity feed
+var AllTransferTypes = []TransferType{TransferTypeCFX, TransferTypeERC20, TransferTypeERC777, TransferTypeERC721, TransferTypeERC1155}
+
+// GetTransferType returns CFX if tokenIdentifier is nil, otherwise ERC20
+func GetTransferType(tokenIdentifier *types.Address) TransferType {
+	if tokenIdentifier == nil {
+		return TransferTypeCFX
+	}
+	return TransferTypeERC20
+}
+
+// ActivityItem represents an item of account activity feed
+type ActivityItem struct {
+	Event  TokenTransferEvent `json:"event"`
+	TxDict *TxDict            `json:"tx_dict"`
+}
+
+// ActivityFeedOption represents options of account activity feed
+type ActivityFeedOption struct {
+	// TransferTypes limits types of items, AllTransferTypes is used if it is empty
+	TransferTypes []TransferType
+	// PageSize is the count of events fetched per request of each type, DefaultHistoryPageSize is used if it is 0
+	PageSize uint
+	// StartTime and EndTime limit the time range of items, the zero value means no limit
+	StartTime time.Time
+	EndTime   time.Time
+	Direction HistoryDirection
+}
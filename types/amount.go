@@ -0,0 +1,125 @@
+package richtypes
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/Conflux-Chain/go-conflux-sdk/constants"
+)
+
+// Decimals of units of CFX
+const (
+	DripDecimal  = 0
+	GDripDecimal = 9
+	CFXDecimal   = constants.CFXDecimal
+)
+
+// FormatUnits formats value in base unit to decimal string according to decimals exactly,
+// for example FormatUnits(1500, 3) returns "1.5".
+func FormatUnits(value *big.Int, decimals uint64) string {
+	if value == nil {
+		return "0"
+	}
+
+	digits := new(big.Int).Abs(value).String()
+	if decimals > 0 {
+		if uint64(len(digits)) <= decimals {
+			digits = strings.Repeat("0", int(decimals)-len(digits)+1) + digits
+		}
+		pointIndex := len(digits) - int(decimals)
+		digits = strings.TrimRight(digits[:pointIndex]+"."+digits[pointIndex:], "0")
+		digits = strings.TrimSuffix(digits, ".")
+	}
+
+	if value.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// ParseUnits parses decimal string to value in base unit according to decimals exactly,
+// for example ParseUnits("1.5", 3) returns 1500.
+// It returns error if amount is not a decimal string or has more fractional digits than decimals.
+func ParseUnits(amount string, decimals uint64) (*big.Int, error) {
+	str := strings.TrimSpace(amount)
+
+	negative := false
+	if strings.HasPrefix(str, "-") || strings.HasPrefix(str, "+") {
+		negative = str[0] == '-'
+		str = str[1:]
+	}
+
+	parts := strings.Split(str, ".")
+	if len(parts) > 2 || (parts[0] == "" && (len(parts) == 1 || parts[1] == "")) {
+		return nil, fmt.Errorf("invalid decimal amount %q", amount)
+	}
+
+	integer := parts[0]
+	fraction := ""
+	if len(parts) == 2 {
+		fraction = strings.TrimRight(parts[1], "0")
+	}
+	if uint64(len(fraction)) > decimals {
+		return nil, fmt.Errorf("amount %q has more than %v fractional digits", amount, decimals)
+	}
+
+	digits := integer + fraction + strings.Repeat("0", int(decimals)-len(fraction))
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return nil, fmt.Errorf("invalid decimal amount %q", amount)
+		}
+	}
+
+	value, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal amount %q", amount)
+	}
+	if negative {
+		value.Neg(value)
+	}
+	return value, nil
+}
+
+// FormatDripToCFX formats drip to decimal string in CFX
+func FormatDripToCFX(drip *big.Int) string {
+	return FormatUnits(drip, CFXDecimal)
+}
+
+// FormatDripToGDrip formats drip to decimal string in GDrip
+func FormatDripToGDrip(drip *big.Int) string {
+	return FormatUnits(drip, GDripDecimal)
+}
+
+// ParseCFXToDrip parses decimal string in CFX to drip
+func ParseCFXToDrip(cfx string) (*big.Int, error) {
+	return ParseUnits(cfx, CFXDecimal)
+}
+
+// ParseGDripToDrip parses decimal string in GDrip to drip
+func ParseGDripToDrip(gdrip string) (*big.Int, error) {
+	return ParseUnits(gdrip, GDripDecimal)
+}
+
+// FormattedBalance returns balance formatted by token decimals
+func (tb *TokenWithBalance) FormattedBalance() (string, error) {
+	return formatRawValue(tb.Balance, tb.TokenDecimal)
+}
+
+// FormattedValue returns value formatted by token decimals
+func (tte *TokenTransferEvent) FormattedValue() (string, error) {
+	return formatRawValue(tte.Value, tte.TokenDecimal)
+}
+
+// FormattedValue returns value formatted by token decimals
+func (tu *TxUnit) FormattedValue() string {
+	return FormatUnits(tu.Value, tu.TokenDecimal)
+}
+
+func formatRawValue(rawValue string, decimals uint64) (string, error) {
+	value, ok := new(big.Int).SetString(rawValue, 0)
+	if !ok {
+		return "", fmt.Errorf("convert %v to *big.Int fail", rawValue)
+	}
+	return FormatUnits(value, decimals), nil
+}
@@ -0,0 +1,65 @@
+package richtypes
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestFormatUnits(t *testing.T) {
+	datas := []struct {
+		value    *big.Int
+		decimals uint64
+		expect   string
+	}{
+		{big.NewInt(0), 18, "0"},
+		{big.NewInt(1), 18, "0.000000000000000001"},
+		{big.NewInt(1500), 3, "1.5"},
+		{big.NewInt(-1500), 3, "-1.5"},
+		{big.NewInt(1000), 0, "1000"},
+	}
+
+	for _, data := range datas {
+		if actual := FormatUnits(data.value, data.decimals); actual != data.expect {
+			t.Errorf("expect: %v, actual: %v", data.expect, actual)
+		}
+	}
+}
+
+func TestParseUnits(t *testing.T) {
+	datas := []struct {
+		amount   string
+		decimals uint64
+		expect   *big.Int
+	}{
+		{"0", 18, big.NewInt(0)},
+		{"0.000000000000000001", 18, big.NewInt(1)},
+		{"1.5", 3, big.NewInt(1500)},
+		{"1.500", 3, big.NewInt(1500)},
+		{".5", 1, big.NewInt(5)},
+		{"-1.5", 3, big.NewInt(-1500)},
+		{"1000", 0, big.NewInt(1000)},
+		{"1.5", 0, nil},
+		{"1.2345", 3, nil},
+		{"1e3", 3, nil},
+		{"1.2.3", 3, nil},
+		{"", 3, nil},
+		{".", 3, nil},
+	}
+
+	for _, data := range datas {
+		actual, err := ParseUnits(data.amount, data.decimals)
+		if data.expect == nil {
+			if err == nil {
+				t.Errorf("expect error for %q, actual: %v", data.amount, actual)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parse %q error: %v", data.amount, err)
+			continue
+		}
+		if actual.Cmp(data.expect) != 0 {
+			t.Errorf("expect: %v, actual: %v", data.expect, actual)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package richtypes
+
+import (
+	"math/big"
+
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TxConfirmationStatus represents the status of a transaction on its way to be finalized
+type TxConfirmationStatus string
+
+const (
+	// TxStatusPending means the transaction is in pool and not packed yet
+	TxStatusPending TxConfirmationStatus = "pending"
+	// TxStatusMined means the transaction is packed in a block but not executed yet
+	TxStatusMined TxConfirmationStatus = "mined"
+	// TxStatusExecuted means the transaction is executed and has a receipt
+	TxStatusExecuted TxConfirmationStatus = "executed"
+	// TxStatusConfirmed means the revert rate of block is under the confirmed risk threshold
+	// or the epoch of transaction is not after the latest confirmed epoch
+	TxStatusConfirmed TxConfirmationStatus = "confirmed"
+	// TxStatusFinalized means the epoch of transaction is not after the latest checkpoint and can not be reverted
+	TxStatusFinalized TxConfirmationStatus = "finalized"
+	// TxStatusDropped means the transaction is not found any more or its nonce is used by another transaction
+	TxStatusDropped TxConfirmationStatus = "dropped"
+)
+
+// TxConfirmationEvent is emitted when status of a tracked transaction changed
+// or revert rate of its block crossed one of the risk thresholds
+type TxConfirmationEvent struct {
+	TxHash     types.Hash           `json:"tx_hash"`
+	Status     TxConfirmationStatus `json:"status"`
+	PrevStatus TxConfirmationStatus `json:"prev_status,omitempty"`
+	BlockHash  *types.Hash          `json:"block_hash,omitempty"`
+	// EpochNumber is the epoch which executes the transaction
+	EpochNumber *hexutil.Uint64 `json:"epoch_number,omitempty"`
+	// OutcomeStatus is 0 if the transaction is executed successfully, it is nil before executed
+	OutcomeStatus *hexutil.Uint64 `json:"outcome_status,omitempty"`
+	RevertRate    *big.Float      `json:"revert_rate,omitempty"`
+	// RiskThreshold is the crossed risk threshold, it is nil if the event is caused by status changing
+	RiskThreshold *big.Float `json:"risk_threshold,omitempty"`
+}
@@ -43,6 +43,16 @@ const (
 	FANSCOIN ContractType = "FANSCOIN"
 	ERC721   ContractType = "ERC721"
 	DEX      ContractType = "DEX"
+	// CROSSSPACE represents CrossSpaceCall internal contract
+	CROSSSPACE ContractType = "CROSSSPACE"
+	// STAKING represents Staking internal contract
+	STAKING ContractType = "STAKING"
+	// SPONSOR represents SponsorWhitelistControl internal contract
+	SPONSOR ContractType = "SPONSOR"
+	// ADMIN represents AdminControl internal contract
+	ADMIN ContractType = "ADMIN"
+	// WCFX represents wrapped CFX contracts
+	WCFX ContractType = "WCFX"
 )
 
 const (
@@ -51,6 +61,12 @@ const (
 	SymbolFunction   ContractElemType = "SymbolFunction"
 	DecimalsFunction ContractElemType = "DecimalsFunction"
 	TransferFunction ContractElemType = "TransferFunction"
+	// TransferFromFunction represents transfer methods with an explicit token holder,
+	// such as erc20 transferFrom, erc777 operatorSend and erc721 transferFrom and safeTransferFrom
+	TransferFromFunction ContractElemType = "TransferFromFunction"
+	// MintEvent and BurnEvent represent events emitted when tokens are created or destroyed, such as erc777 Minted and Burned
+	MintEvent ContractElemType = "MintEvent"
+	BurnEvent ContractElemType = "BurnEvent"
 )
 
 // Contract describe response contract information of scan rest api request
@@ -108,6 +124,12 @@ func (c *Contract) GetContractTypeByABI() ContractType {
 	if err == nil && method != nil {
 		return ERC777
 	}
+
+	erc721sign, _ := hexutil.Decode(constants.Erc721OwnerOfFuncSign)
+	method, err = realContract.ABI.MethodById(erc721sign)
+	if err == nil && method != nil {
+		return ERC721
+	}
 	return UNKNOWN
 }
 
@@ -147,7 +169,20 @@ func (contrete *ContractElemConcrete) DecodeEvent(log *types.Log) (eventParmsPtr
 			eventParmsPtr = &params
 			return
 		}
-
+	case MintEvent:
+		if contrete.ContractType == ERC777 {
+			params := ERC777MintedEventParams{}
+			err = contrete.Contract.DecodeEvent(&params, contrete.ElemName, *log)
+			eventParmsPtr = &params
+			return
+		}
+	case BurnEvent:
+		if contrete.ContractType == ERC777 {
+			params := ERC777BurnedEventParams{}
+			err = contrete.Contract.DecodeEvent(&params, contrete.ElemName, *log)
+			eventParmsPtr = &params
+			return
+		}
 	}
 
 	return nil, fmt.Errorf("not found tuple type for contract type: %v, event type: %v", contrete.ContractType, contrete.ElemType)
@@ -189,6 +224,42 @@ func (contrete *ContractElemConcrete) DecodeFunction(data []byte) (functionParms
 			functionParmsPtr = &params
 			return
 		}
+	case TransferFromFunction:
+		var result []interface{}
+		result, err = method.Inputs.Unpack(data[4:])
+		if err != nil {
+			return nil, err
+		}
+
+		switch contrete.ContractType {
+		case ERC20:
+			params := ERC20TokenTransferFromFunctionParams{}
+			params.From = result[0].(common.Address)
+			params.To = result[1].(common.Address)
+			params.Value = result[2].(*big.Int)
+			functionParmsPtr = &params
+			return
+		case ERC777:
+			params := ERC777TokenOperatorSendFunctionParams{}
+			params.From = result[0].(common.Address)
+			params.To = result[1].(common.Address)
+			params.Amount = result[2].(*big.Int)
+			params.Data = result[3].([]byte)
+			params.OperatorData = result[4].([]byte)
+			functionParmsPtr = &params
+			return
+		case ERC721:
+			params := ERC721TokenTransferFromFunctionParams{}
+			params.From = result[0].(common.Address)
+			params.To = result[1].(common.Address)
+			params.TokenId = result[2].(*big.Int)
+			// safeTransferFrom is overloaded with an optional data parameter
+			if len(result) > 3 {
+				params.Data = result[3].([]byte)
+			}
+			functionParmsPtr = &params
+			return
+		}
 	}
 	return nil, fmt.Errorf("not found tuple type for contract type: %v, function type: %v", contrete.ContractType, contrete.ElemType)
 }
@@ -0,0 +1,37 @@
+package richtypes
+
+import (
+	"math/big"
+
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+)
+
+// MovementDirection represents whether the watched address receives or sends the asset
+type MovementDirection string
+
+const (
+	// MovementIn means the watched address receives the asset
+	MovementIn MovementDirection = "in"
+	// MovementOut means the watched address sends the asset
+	MovementOut MovementDirection = "out"
+)
+
+// Movement is a transfer of CFX or token touching a watched address
+type Movement struct {
+	TxUnit
+	Direction  MovementDirection    `json:"direction"`
+	TxHash     types.Hash           `json:"tx_hash"`
+	BlockHash  *types.Hash          `json:"block_hash,omitempty"`
+	TxAt       JSONTime             `json:"tx_at"`
+	RevertRate *big.Float           `json:"revert_rate,omitempty"`
+	Status     TxConfirmationStatus `json:"status"`
+}
+
+// EpochMovements represents movements of an epoch emitted by DepositWatcher
+type EpochMovements struct {
+	EpochNumber uint64     `json:"epoch_number"`
+	PivotHash   types.Hash `json:"pivot_hash"`
+	Movements   []Movement `json:"movements"`
+	// Reverted is true if the epoch is dropped by pivot chain reorg, the movements emitted before for the epoch should be reverted
+	Reverted bool `json:"reverted"`
+}
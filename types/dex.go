@@ -0,0 +1,42 @@
+package richtypes
+
+import (
+	"math/big"
+
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+)
+
+// SwapSummary summarizes tokens swapped by Uniswap V2 style DEX, the tokens swapped through multiple pairs
+// are summarized from the input of the first pair to the output of the last pair.
+type SwapSummary struct {
+	// Pairs are pair contracts in swap order, it is empty if the summary is decoded from router method
+	Pairs     []types.Address `json:"pairs,omitempty"`
+	TokenIn   *types.Address  `json:"token_in"`
+	AmountIn  *big.Int        `json:"amount_in"`
+	TokenOut  *types.Address  `json:"token_out"`
+	AmountOut *big.Int        `json:"amount_out"`
+	To        *types.Address  `json:"to,omitempty"`
+	// IsLimit is true if the summary is decoded from router method of unsigned transaction,
+	// in which case one of AmountIn and AmountOut is the max amount in or min amount out.
+	IsLimit bool `json:"is_limit,omitempty"`
+}
+
+// LiquidityAction represents adding or removing liquidity of DEX pair
+type LiquidityAction string
+
+const (
+	// LiquidityAdd means liquidity is added by Mint event of pair
+	LiquidityAdd LiquidityAction = "add"
+	// LiquidityRemove means liquidity is removed by Burn event of pair
+	LiquidityRemove LiquidityAction = "remove"
+)
+
+// LiquidityChange represents tokens added to or removed from DEX pair
+type LiquidityChange struct {
+	Pair    types.Address   `json:"pair"`
+	Action  LiquidityAction `json:"action"`
+	Token0  *types.Address  `json:"token0"`
+	Amount0 *big.Int        `json:"amount0"`
+	Token1  *types.Address  `json:"token1"`
+	Amount1 *big.Int        `json:"amount1"`
+}
@@ -0,0 +1,40 @@
+package richtypes
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Conflux-Chain/go-conflux-sdk/constants"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+)
+
+var (
+	// ErrTransactionPacked is returned when replacing a transaction which is already packed
+	ErrTransactionPacked = errors.New("transaction is already packed")
+	// ErrTransactionNotFound is returned when the transaction is not found in both chain and transaction pool
+	ErrTransactionNotFound = errors.New("transaction not found")
+	// ErrNotFound is returned when scan server responds the requested resource is not found
+	ErrNotFound = errors.New("not found")
+)
+
+// InsufficientFundsError is returned when the balance of account is not enough for sending transaction
+type InsufficientFundsError struct {
+	Address types.Address
+	// TokenIdentifier is the token contract address, it is nil for CFX
+	TokenIdentifier *types.Address
+	TokenCode       string
+	// Required is the required amount in base unit, it contains value, gas fee and storage collateral for CFX
+	Required *big.Int
+	// Balance is the balance of account in base unit
+	Balance *big.Int
+}
+
+// Error implements interface error
+func (e *InsufficientFundsError) Error() string {
+	tokenCode := e.TokenCode
+	if e.TokenIdentifier == nil && tokenCode == "" {
+		tokenCode = constants.CFXSymbol
+	}
+	return fmt.Sprintf("insufficient %v balance of %v, required: %v, balance: %v", tokenCode, e.Address, e.Required, e.Balance)
+}
@@ -28,12 +28,56 @@ type ERC777TokenTransferEventParams struct {
 	OperatorData []byte
 }
 
+// ERC777MintedEventParams represents Minted event of erc777
+type ERC777MintedEventParams struct {
+	Operator     common.Address
+	To           common.Address
+	Amount       *big.Int
+	Data         []byte
+	OperatorData []byte
+}
+
+// ERC777BurnedEventParams represents Burned event of erc777
+type ERC777BurnedEventParams struct {
+	Operator     common.Address
+	From         common.Address
+	Amount       *big.Int
+	Data         []byte
+	OperatorData []byte
+}
+
 // ERC721TokenTransferEventParams ...
 type ERC721TokenTransferEventParams struct {
 	TokenTransferEventParams
 	TokenId *big.Int
 }
 
+// CrossSpaceCallEventParams represents Call event of CrossSpaceCall, Receiver is the eSpace address
+type CrossSpaceCallEventParams struct {
+	Sender   [20]byte
+	Receiver [20]byte
+	Value    *big.Int
+	Nonce    *big.Int
+	Data     []byte
+}
+
+// CrossSpaceCreateEventParams represents Create event of CrossSpaceCall, ContractAddress is the eSpace address
+type CrossSpaceCreateEventParams struct {
+	Sender          [20]byte
+	ContractAddress [20]byte
+	Value           *big.Int
+	Nonce           *big.Int
+	Init            []byte
+}
+
+// CrossSpaceWithdrawEventParams represents Withdraw event of CrossSpaceCall, Sender is the eSpace mapped address
+type CrossSpaceWithdrawEventParams struct {
+	Sender   [20]byte
+	Receiver common.Address
+	Value    *big.Int
+	Nonce    *big.Int
+}
+
 // CreateEventParams ...
 func CreateEventParams(contractType ContractType, eventType ContractElemType) (interface{}, error) {
 	switch eventType {
@@ -51,3 +95,40 @@ func CreateEventParams(contractType ContractType, eventType ContractElemType) (i
 	}
 	return nil, fmt.Errorf("not found tuple type for contract type: %v, event type: %v", contractType, eventType)
 }
+
+// WrappedCFXDepositEventParams represents Deposit or Minted event of wrapped CFX, To receives the wrapped token
+type WrappedCFXDepositEventParams struct {
+	To     common.Address
+	Amount *big.Int
+}
+
+// WrappedCFXWithdrawalEventParams represents Withdrawal or Burned event of wrapped CFX, From receives the unwrapped CFX
+type WrappedCFXWithdrawalEventParams struct {
+	From   common.Address
+	Amount *big.Int
+}
+
+// DexSwapEventParams represents Swap event of Uniswap V2 style pair
+type DexSwapEventParams struct {
+	Sender     common.Address
+	Amount0In  *big.Int
+	Amount1In  *big.Int
+	Amount0Out *big.Int
+	Amount1Out *big.Int
+	To         common.Address
+}
+
+// DexMintEventParams represents Mint event of Uniswap V2 style pair, which is emitted when liquidity is added
+type DexMintEventParams struct {
+	Sender  common.Address
+	Amount0 *big.Int
+	Amount1 *big.Int
+}
+
+// DexBurnEventParams represents Burn event of Uniswap V2 style pair, which is emitted when liquidity is removed
+type DexBurnEventParams struct {
+	Sender  common.Address
+	Amount0 *big.Int
+	Amount1 *big.Int
+	To      common.Address
+}
@@ -0,0 +1,82 @@
+package richtypes
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ExportFormat represents format of exported account history
+type ExportFormat string
+
+const (
+	// ExportFormatCSV is csv with columns specified by ExportOption.Columns
+	ExportFormatCSV ExportFormat = "csv"
+	// ExportFormatKoinly is the universal csv format of Koinly
+	ExportFormatKoinly ExportFormat = "koinly"
+	// ExportFormatCoinTracking is the csv import format of CoinTracking
+	ExportFormatCoinTracking ExportFormat = "cointracking"
+)
+
+// ExportColumn represents column of exported csv
+type ExportColumn string
+
+const (
+	// ExportColumnTime is the time of transaction in RFC3339 format of UTC
+	ExportColumnTime         ExportColumn = "time"
+	ExportColumnDirection    ExportColumn = "direction"
+	ExportColumnCounterparty ExportColumn = "counterparty"
+	ExportColumnTokenSymbol  ExportColumn = "token_symbol"
+	// ExportColumnAmount is the amount formatted by token decimals
+	ExportColumnAmount ExportColumn = "amount"
+	// ExportColumnFee is the gas fee in CFX paid by the account
+	ExportColumnFee    ExportColumn = "fee"
+	ExportColumnStatus ExportColumn = "status"
+	ExportColumnTxHash ExportColumn = "tx_hash"
+)
+
+// DefaultExportColumns contains all columns in default order
+var DefaultExportColumns = []ExportColumn{
+	ExportColumnTime, ExportColumnDirection, ExportColumnCounterparty, ExportColumnTokenSymbol,
+	ExportColumnAmount, ExportColumnFee, ExportColumnStatus, ExportColumnTxHash,
+}
+
+// ExportStatus represents confirmation status of exported row
+type ExportStatus string
+
+const (
+	ExportStatusConfirmed ExportStatus = "confirmed"
+	ExportStatusPending   ExportStatus = "pending"
+	// ExportStatusUnknown is used when the revert rate is unknown
+	ExportStatusUnknown ExportStatus = "unknown"
+	// ExportStatusFailed is used for failed transaction, which is exported as a row of the gas fee only
+	ExportStatusFailed ExportStatus = "failed"
+)
+
+// ExportOption represents options of exporting account history
+type ExportOption struct {
+	// Format is ExportFormatCSV if it is empty
+	Format ExportFormat
+	// Columns is only used by ExportFormatCSV, DefaultExportColumns is used if it is empty
+	Columns []ExportColumn
+	// ConfirmedRiskThreshold is the max revert rate of block for regarding transaction as confirmed, default is 1e-8
+	ConfirmedRiskThreshold *big.Float
+}
+
+// ExportRow represents a movement of the account in exported history
+type ExportRow struct {
+	Time      time.Time
+	Direction HistoryDirection
+	// Counterparty is nil for mint and burn
+	Counterparty *types.Address
+	// EVMCounterparty is the hex address of counterparty for eSpace movement, in which case Counterparty is nil
+	EVMCounterparty *common.Address
+	TokenSymbol     string
+	Amount          string
+	// Fee is the gas fee in CFX, it is only set for the first row of transaction sent by the account
+	Fee    string
+	Status ExportStatus
+	TxHash types.Hash
+}
@@ -16,3 +16,72 @@ type ERC777TokenTransferFunctionParams struct {
 	Amount *big.Int
 	Data   []byte
 }
+
+type ERC20TokenTransferFromFunctionParams struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+}
+
+type ERC777TokenOperatorSendFunctionParams struct {
+	From         common.Address
+	To           common.Address
+	Amount       *big.Int
+	Data         []byte
+	OperatorData []byte
+}
+
+type ERC721TokenTransferFromFunctionParams struct {
+	From    common.Address
+	To      common.Address
+	TokenId *big.Int
+	Data    []byte
+}
+
+// CrossSpaceTransferEVMFunctionParams represents params of transferEVM and callEVM of CrossSpaceCall, To is the eSpace address
+type CrossSpaceTransferEVMFunctionParams struct {
+	To   common.Address
+	Data []byte
+}
+
+// CrossSpaceWithdrawFunctionParams represents params of withdrawFromMapped of CrossSpaceCall
+type CrossSpaceWithdrawFunctionParams struct {
+	Value *big.Int
+}
+
+// StakingDepositFunctionParams represents params of deposit of Staking
+type StakingDepositFunctionParams struct {
+	Amount *big.Int
+}
+
+// StakingWithdrawFunctionParams represents params of withdraw of Staking
+type StakingWithdrawFunctionParams struct {
+	Amount *big.Int
+}
+
+// StakingVoteLockFunctionParams represents params of voteLock of Staking
+type StakingVoteLockFunctionParams struct {
+	Amount            *big.Int
+	UnlockBlockNumber *big.Int
+}
+
+// WrappedCFXDepositFunctionParams represents params of deposit of wrapped CFX, the amount is the value of transaction
+type WrappedCFXDepositFunctionParams struct {
+}
+
+// WrappedCFXWithdrawFunctionParams represents params of withdraw of wrapped CFX
+type WrappedCFXWithdrawFunctionParams struct {
+	Amount *big.Int
+}
+
+// DexSwapFunctionParams represents params of swap methods of Uniswap V2 style router,
+// AmountIn is nil if it is the value of transaction for methods swapping exact CFX.
+type DexSwapFunctionParams struct {
+	// ExactIn is true if AmountIn is exact and AmountOut is the min amount out,
+	// otherwise AmountOut is exact and AmountIn is the max amount in.
+	ExactIn   bool
+	AmountIn  *big.Int
+	AmountOut *big.Int
+	Path      []common.Address
+	To        common.Address
+}
@@ -0,0 +1,71 @@
+package richtypes
+
+import (
+	"time"
+
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+)
+
+// HistoryCursor represents the position of token transfer event in account history,
+// the history is ordered by epoch number, transaction index and log index descendingly.
+type HistoryCursor struct {
+	EpochNumber      uint64 `json:"epochNumber"`
+	TransactionIndex uint64 `json:"transactionIndex"`
+	LogIndex         uint   `json:"logIndex"`
+}
+
+// Compare returns -1, 0 or 1 if cursor is older than, same as or newer than other
+func (hc HistoryCursor) Compare(other HistoryCursor) int {
+	left := []uint64{hc.EpochNumber, hc.TransactionIndex, uint64(hc.LogIndex)}
+	right := []uint64{other.EpochNumber, other.TransactionIndex, uint64(other.LogIndex)}
+	for i := range left {
+		if left[i] < right[i] {
+			return -1
+		}
+		if left[i] > right[i] {
+			return 1
+		}
+	}
+	return 0
+}
+
+// Cursor returns position of token transfer event in account history
+func (tte *TokenTransferEvent) Cursor() HistoryCursor {
+	return HistoryCursor{
+		EpochNumber:      tte.EpochNumber,
+		TransactionIndex: tte.TransactionIndex,
+		LogIndex:         tte.TransactionLogIndex,
+	}
+}
+
+// HistoryDirection represents direction of token transfer relative to the account
+type HistoryDirection string
+
+const (
+	// HistoryDirectionAll matches all transfers
+	HistoryDirectionAll HistoryDirection = ""
+	// HistoryDirectionIn matches transfers to the account
+	HistoryDirectionIn HistoryDirection = "in"
+	// HistoryDirectionOut matches transfers from the account
+	HistoryDirectionOut HistoryDirection = "out"
+)
+
+// AccountHistoryOption represents options of iterating account history
+type AccountHistoryOption struct {
+	// TokenIdentifier is the token contract address, the history of CFX is iterated if both it and TransferType are empty
+	TokenIdentifier *types.Address
+	// TransferType is decided by TokenIdentifier if it is empty, otherwise transfers of all tokens of
+	// the type are iterated if TokenIdentifier is nil
+	TransferType TransferType
+	// PageSize is the count of events fetched per request, DefaultHistoryPageSize is used if it is 0
+	PageSize uint
+	// StartTime and EndTime limit the time range of events, the zero value means no limit
+	StartTime time.Time
+	EndTime   time.Time
+	Direction HistoryDirection
+	// After is the cursor of the last event got by previous iterating, only events older than it are returned
+	After *HistoryCursor
+}
+
+// DefaultHistoryPageSize is the default count of events fetched per request
+const DefaultHistoryPageSize = 50
@@ -0,0 +1,20 @@
+package richtypes
+
+import (
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// PayoutEntry represents a payment of batch payout, the TokenIdentifier is nil for CFX
+type PayoutEntry struct {
+	To              types.Address  `json:"to"`
+	Amount          *hexutil.Big   `json:"amount"`
+	TokenIdentifier *types.Address `json:"token_identifier,omitempty"`
+}
+
+// PayoutTransaction contains unsigned transaction of a payout entry and its TxDictBase for auditing
+type PayoutTransaction struct {
+	Entry  PayoutEntry                `json:"entry"`
+	Tx     *types.UnsignedTransaction `json:"tx"`
+	TxDict *TxDictBase                `json:"tx_dict"`
+}
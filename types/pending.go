@@ -0,0 +1,38 @@
+package richtypes
+
+import (
+	"math/big"
+
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+)
+
+// PendingStatus represents why a transaction in pool is not packed yet
+type PendingStatus string
+
+const (
+	// PendingReady means the transaction is ready to be packed
+	PendingReady PendingStatus = "ready"
+	// PendingFutureNonce means there is a nonce gap before the transaction
+	PendingFutureNonce PendingStatus = "futureNonce"
+	// PendingNotEnoughCash means the balance of sender is not enough for the transaction
+	PendingNotEnoughCash PendingStatus = "notEnoughCash"
+)
+
+// PendingTxDict is another representation of transaction in pool with its pending status
+type PendingTxDict struct {
+	TxDictBase
+	TxHash types.Hash    `json:"tx_hash"`
+	Nonce  *big.Int      `json:"nonce"`
+	Status PendingStatus `json:"status"`
+}
+
+// AccountPendingTxDicts describes pending transactions of an account
+type AccountPendingTxDicts struct {
+	Address types.Address `json:"address"`
+	// StateNonce is the nonce of account in the latest state
+	StateNonce *big.Int `json:"state_nonce"`
+	// PendingNonce is the next nonce of account counting transactions in pool
+	PendingNonce *big.Int        `json:"pending_nonce"`
+	PendingCount uint64          `json:"pending_count"`
+	List         []PendingTxDict `json:"list"`
+}
@@ -0,0 +1,53 @@
+package richtypes
+
+import (
+	"math/big"
+
+	sdk "github.com/Conflux-Chain/go-conflux-sdk"
+	"github.com/Conflux-Chain/go-conflux-sdk/constants"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// GasPriceStrategy returns gas price for creating transaction
+type GasPriceStrategy func(client sdk.ClientOperator) (*hexutil.Big, error)
+
+// SendTokenOption represents options for creating send token transaction,
+// the fields are filled by conflux node when they are empty.
+type SendTokenOption struct {
+	// GasPriceStrategy is used when GasPrice is nil, the gas price of node is used if both are nil
+	GasPriceStrategy GasPriceStrategy
+	GasPrice         *hexutil.Big
+	Nonce            *hexutil.Big
+	StorageLimit     *hexutil.Uint64
+	EpochHeight      *hexutil.Uint64
+	ChainID          *hexutil.Uint
+	// CheckBalance checks that sender holds enough CFX and token for the transaction if true
+	CheckBalance bool
+}
+
+// FixedGasPrice returns a GasPriceStrategy which always uses gasPrice
+func FixedGasPrice(gasPrice *hexutil.Big) GasPriceStrategy {
+	return func(client sdk.ClientOperator) (*hexutil.Big, error) {
+		return gasPrice, nil
+	}
+}
+
+// ScaledGasPrice returns a GasPriceStrategy which uses gas price of conflux node multiplied by percent/100,
+// the result is not less than the min gas price.
+func ScaledGasPrice(percent uint64) GasPriceStrategy {
+	return func(client sdk.ClientOperator) (*hexutil.Big, error) {
+		gasPrice, err := client.GetGasPrice()
+		if err != nil {
+			return nil, errors.Wrap(err, "get gas price error")
+		}
+
+		scaled := new(big.Int).Mul(gasPrice.ToInt(), new(big.Int).SetUint64(percent))
+		scaled.Div(scaled, big.NewInt(100))
+		if scaled.Cmp(big.NewInt(constants.MinGasprice)) < 0 {
+			scaled = big.NewInt(constants.MinGasprice)
+		}
+		return types.NewBigIntByRaw(scaled), nil
+	}
+}
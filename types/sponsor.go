@@ -0,0 +1,32 @@
+package richtypes
+
+import (
+	"math/big"
+
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+)
+
+// SponsorInfo represents sponsors of contract set by SponsorWhitelistControl and whether the sender is whitelisted
+type SponsorInfo struct {
+	Contract                    types.Address  `json:"contract"`
+	SponsorForGas               *types.Address `json:"sponsor_for_gas,omitempty"`
+	SponsorForCollateral        *types.Address `json:"sponsor_for_collateral,omitempty"`
+	SponsorGasBound             *big.Int       `json:"sponsor_gas_bound"`
+	SponsorBalanceForGas        *big.Int       `json:"sponsor_balance_for_gas"`
+	SponsorBalanceForCollateral *big.Int       `json:"sponsor_balance_for_collateral"`
+	// Sender is the account checked by IsWhitelisted, it is nil if not specified
+	Sender        *types.Address `json:"sender,omitempty"`
+	IsWhitelisted bool           `json:"is_whitelisted"`
+}
+
+// FeeEstimate represents fee of unsigned transaction with sponsorship of the contract, all values are in drip
+type FeeEstimate struct {
+	// GasFee is gas * gasPrice
+	GasFee *big.Int `json:"gas_fee"`
+	// StorageCollateral is the max cfx collateralized for storage limit
+	StorageCollateral       *big.Int `json:"storage_collateral"`
+	GasCoveredBySponsor     bool     `json:"gas_covered_by_sponsor"`
+	StorageCoveredBySponsor bool     `json:"storage_covered_by_sponsor"`
+	// SenderFee is the part of GasFee and StorageCollateral paid by sender
+	SenderFee *big.Int `json:"sender_fee"`
+}
@@ -0,0 +1,32 @@
+package richtypes
+
+import (
+	"math/big"
+)
+
+// StakingAction represents the operation of Staking internal contract which moves CFX to or from staked state
+type StakingAction string
+
+const (
+	// StakingDeposit moves CFX from balance to staking balance
+	StakingDeposit StakingAction = "deposit"
+	// StakingWithdraw moves CFX from staking balance to balance
+	StakingWithdraw StakingAction = "withdraw"
+	// StakingVoteLock locks staking balance until the unlock block number, the balance is not changed
+	StakingVoteLock StakingAction = "vote_lock"
+)
+
+// StakingInfo represents staking state of an account
+type StakingInfo struct {
+	StakingBalance *big.Int `json:"staking_balance"`
+	// LockedStakingBalance is the staking balance locked at BlockNumber, which could not be withdrawn
+	LockedStakingBalance *big.Int        `json:"locked_staking_balance"`
+	BlockNumber          uint64          `json:"block_number"`
+	UnlockSchedule       []StakingUnlock `json:"unlock_schedule"`
+}
+
+// StakingUnlock represents that Amount of staking balance is unlocked at UnlockBlockNumber
+type StakingUnlock struct {
+	Amount            *big.Int `json:"amount"`
+	UnlockBlockNumber uint64   `json:"unlock_block_number"`
+}
@@ -5,9 +5,11 @@
 package richtypes
 
 import (
+	"encoding/json"
 	"math/big"
 
 	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // Token describes token detail messages, such as erc20, erc777, fanscoin and so on.
@@ -30,6 +32,8 @@ type TokenWithBalance struct {
 	Token
 	Balance string        `json:"balance"`
 	Address types.Address `json:"address"`
+	// Reputation is set when token reputation checker of rich client is set
+	Reputation *TokenReputation `json:"reputation,omitempty"`
 }
 
 // TokenWithBlanceList describes list of token with balance
@@ -43,13 +47,45 @@ type TokenTransferEvent struct {
 	ContractAddress     *types.Address `json:"address,omitempty"`
 	TransactionHash     types.Hash     `json:"transactionHash"`
 	TransactionLogIndex uint           `json:"transactionLogIndex"`
+	TransactionIndex    uint64         `json:"transactionIndex"`
+	EpochNumber         uint64         `json:"epochNumber"`
 	From                types.Address  `json:"from"`
 	To                  *types.Address `json:"to"`
 	Value               string         `json:"value"`
 	Timestamp           JSONTime       `json:"timestamp"`
 	BlockHash           types.Hash     `json:"blockHash"`
 	RevertRate          *big.Float     `json:"revertRate"`
-	// Status          uint64        `json:"status"`
+	// Status is the execution status of transaction, 0 for success and others for failure,
+	// it is only set for transactions of main coin
+	Status uint64 `json:"status,omitempty"`
+
+	// EVMContractAddress, EVMFrom and EVMTo are hex addresses of event got by ESpaceRichClient,
+	// in which case ContractAddress, From and To are empty.
+	EVMContractAddress *common.Address `json:"evmAddress,omitempty"`
+	EVMFrom            *common.Address `json:"evmFrom,omitempty"`
+	EVMTo              *common.Address `json:"evmTo,omitempty"`
+
+	// Reputation is set when token reputation checker of rich client is set
+	Reputation *TokenReputation `json:"reputation,omitempty"`
+	// TransferType is the type of transfer list which the event comes from
+	TransferType TransferType `json:"transferType,omitempty"`
+	// TokenID is the id of non-fungible token, such as erc721 and erc1155
+	TokenID string `json:"tokenId,omitempty"`
+	// GasFee is the gas fee in drip of transaction, it is only set for transactions of main coin
+	GasFee string `json:"gasFee,omitempty"`
+}
+
+// MarshalJSON implements interface Marshaler, the empty From is marshaled to null for eSpace event
+func (tte TokenTransferEvent) MarshalJSON() ([]byte, error) {
+	type tokenTransferEvent TokenTransferEvent
+	if tte.EVMFrom == nil {
+		return json.Marshal(tokenTransferEvent(tte))
+	}
+
+	return json.Marshal(struct {
+		tokenTransferEvent
+		From *types.Address `json:"from"`
+	}{tokenTransferEvent(tte), nil})
 }
 
 // TokenTransferEventList describes list of token tranfer event information
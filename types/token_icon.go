@@ -0,0 +1,218 @@
+package richtypes
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// MIME types of token icon
+const (
+	TokenIconPNG  = "image/png"
+	TokenIconJPEG = "image/jpeg"
+	TokenIconSVG  = "image/svg+xml"
+)
+
+// TokenIconSizeLimits are the max bytes of token icon by MIME type, icons of other MIME types are invalid
+var TokenIconSizeLimits = map[string]int{
+	TokenIconPNG:  256 * 1024,
+	TokenIconJPEG: 256 * 1024,
+	TokenIconSVG:  64 * 1024,
+}
+
+// TokenIcon represents decoded and validated token icon
+type TokenIcon struct {
+	Data     []byte `json:"data"`
+	MIMEType string `json:"mime_type"`
+	// Hash is the hex encoded sha256 of Data
+	Hash string `json:"hash"`
+}
+
+// NewTokenIcon validates icon data and creates TokenIcon, the MIME type is detected by content,
+// and it should be same as declaredMIMEType if declaredMIMEType is not empty.
+func NewTokenIcon(data []byte, declaredMIMEType string) (*TokenIcon, error) {
+	mimeType := DetectTokenIconMIMEType(data)
+	if mimeType == "" {
+		return nil, fmt.Errorf("unsupported icon format, only png, jpeg and svg are supported")
+	}
+
+	declaredMIMEType = strings.ToLower(strings.TrimSpace(strings.Split(declaredMIMEType, ";")[0]))
+	if declaredMIMEType != "" && declaredMIMEType != mimeType {
+		return nil, fmt.Errorf("declared MIME type %v is not the detected %v", declaredMIMEType, mimeType)
+	}
+
+	if limit := TokenIconSizeLimits[mimeType]; len(data) > limit {
+		return nil, fmt.Errorf("size %v of %v icon exceeds limit %v", len(data), mimeType, limit)
+	}
+
+	var err error
+	switch mimeType {
+	case TokenIconPNG:
+		_, err = png.DecodeConfig(bytes.NewReader(data))
+	case TokenIconJPEG:
+		_, err = jpeg.DecodeConfig(bytes.NewReader(data))
+	case TokenIconSVG:
+		err = validateSVG(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v icon: %v", mimeType, err)
+	}
+
+	hash := sha256.Sum256(data)
+	return &TokenIcon{
+		Data:     data,
+		MIMEType: mimeType,
+		Hash:     hex.EncodeToString(hash[:]),
+	}, nil
+}
+
+// validateSVG parses svg and checks that it is safe to be rendered by wallets, scripts, event handler attributes,
+// foreignObject, entity declarations and references to external resources are not allowed.
+func validateSVG(data []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	hasRoot := false
+	inStyle := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			name := strings.ToLower(t.Name.Local)
+			if !hasRoot && name != "svg" {
+				return fmt.Errorf("root element %v is not svg", t.Name.Local)
+			}
+			hasRoot = true
+
+			if name == "script" || name == "foreignobject" {
+				return fmt.Errorf("element %v is not allowed", t.Name.Local)
+			}
+			inStyle = name == "style"
+
+			for _, attr := range t.Attr {
+				attrName := strings.ToLower(attr.Name.Local)
+				if strings.HasPrefix(attrName, "on") {
+					return fmt.Errorf("event handler attribute %v is not allowed", attr.Name.Local)
+				}
+				if attrName == "href" && !strings.HasPrefix(strings.TrimSpace(attr.Value), "#") {
+					return fmt.Errorf("external reference %v is not allowed", attr.Value)
+				}
+				if hasExternalURL(attr.Value) {
+					return fmt.Errorf("external url in attribute %v is not allowed", attr.Name.Local)
+				}
+			}
+		case xml.EndElement:
+			inStyle = false
+		case xml.CharData:
+			if inStyle && (hasExternalURL(string(t)) || strings.Contains(strings.ToLower(string(t)), "@import")) {
+				return fmt.Errorf("external url in style is not allowed")
+			}
+		case xml.Directive:
+			if strings.Contains(strings.ToUpper(string(t)), "ENTITY") {
+				return fmt.Errorf("entity declaration is not allowed")
+			}
+		}
+	}
+
+	if !hasRoot {
+		return fmt.Errorf("svg element not found")
+	}
+	return nil
+}
+
+// hasExternalURL returns true if css url() in value refers to resource other than fragment of the document
+func hasExternalURL(value string) bool {
+	lower := strings.ToLower(value)
+	for {
+		index := strings.Index(lower, "url(")
+		if index < 0 {
+			return false
+		}
+		lower = lower[index+len("url("):]
+		if !strings.HasPrefix(strings.TrimLeft(lower, " \t\n\r'\""), "#") {
+			return true
+		}
+	}
+}
+
+// ParseTokenIcon decodes icon returned by contract manager, which is data URI or base64 encoded data
+func ParseTokenIcon(icon string) (*TokenIcon, error) {
+	icon = strings.TrimSpace(icon)
+	if !strings.HasPrefix(icon, "data:") {
+		data, err := base64.StdEncoding.DecodeString(icon)
+		if err != nil {
+			return nil, fmt.Errorf("icon is neither data URI nor base64 encoded: %v", err)
+		}
+		return NewTokenIcon(data, "")
+	}
+
+	// data:[<mediatype>][;base64],<data>
+	comma := strings.Index(icon, ",")
+	if comma < 0 {
+		return nil, fmt.Errorf("invalid data URI without comma")
+	}
+	meta, payload := icon[len("data:"):comma], icon[comma+1:]
+
+	var data []byte
+	var err error
+	if strings.HasSuffix(meta, ";base64") {
+		meta = strings.TrimSuffix(meta, ";base64")
+		data, err = base64.StdEncoding.DecodeString(payload)
+	} else {
+		var unescaped string
+		unescaped, err = url.PathUnescape(payload)
+		data = []byte(unescaped)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decode data of data URI error: %v", err)
+	}
+	return NewTokenIcon(data, meta)
+}
+
+// DetectTokenIconMIMEType returns MIME type of icon data by content, it returns empty string for unsupported format
+func DetectTokenIconMIMEType(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return TokenIconPNG
+	case bytes.HasPrefix(data, []byte{0xff, 0xd8, 0xff}):
+		return TokenIconJPEG
+	}
+
+	text := strings.ToLower(strings.TrimSpace(string(data)))
+	if (strings.HasPrefix(text, "<?xml") || strings.HasPrefix(text, "<svg") || strings.HasPrefix(text, "<!doctype svg")) &&
+		strings.Contains(text, "<svg") {
+		return TokenIconSVG
+	}
+	return ""
+}
+
+// DataURI returns icon as base64 encoded data URI
+func (ti *TokenIcon) DataURI() string {
+	return fmt.Sprintf("data:%v;base64,%v", ti.MIMEType, base64.StdEncoding.EncodeToString(ti.Data))
+}
+
+// Extension returns file extension of icon by MIME type
+func (ti *TokenIcon) Extension() string {
+	switch ti.MIMEType {
+	case TokenIconPNG:
+		return ".png"
+	case TokenIconJPEG:
+		return ".jpg"
+	case TokenIconSVG:
+		return ".svg"
+	}
+	return ""
+}
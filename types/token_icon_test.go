@@ -0,0 +1,53 @@
+package richtypes
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestParseTokenIcon(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatal(err)
+	}
+	pngIcon, err := NewTokenIcon(buf.Bytes(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := ParseTokenIcon(pngIcon.DataURI())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.MIMEType != TokenIconPNG || parsed.Hash != pngIcon.Hash {
+		t.Errorf("expect png icon %v, actual: %v %v", pngIcon.Hash, parsed.MIMEType, parsed.Hash)
+	}
+
+	svg := `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" width="1" height="1">` +
+		`<defs><linearGradient id="g"/></defs><rect fill="url(#g)"/><use xlink:href="#g"/></svg>`
+	if icon, err := ParseTokenIcon("data:image/svg+xml," + svg); err != nil || icon.MIMEType != TokenIconSVG {
+		t.Errorf("expect svg icon, actual: %+v, error: %v", icon, err)
+	}
+
+	invalids := map[string]string{
+		"mismatched MIME type": strings.Replace(pngIcon.DataURI(), TokenIconPNG, TokenIconJPEG, 1),
+		"svg with script":      `data:image/svg+xml,<svg><script>alert(1)</script></svg>`,
+		"svg with event":       `data:image/svg+xml,<svg><rect ONload="alert(1)"/></svg>`,
+		"svg with html":        `data:image/svg+xml,<svg><foreignObject><p>x</p></foreignObject></svg>`,
+		"svg with external":    `data:image/svg+xml,<svg><image xlink:href="https://example.com/a.png"/></svg>`,
+		"svg with css url":     `data:image/svg+xml,<svg><style>rect{fill:url( 'https://example.com/a')}</style></svg>`,
+		"svg with entity":      `data:image/svg+xml,<!DOCTYPE svg [<!ENTITY a "b">]><svg></svg>`,
+		"malformed svg":        `data:image/svg+xml,<svg><rect></svg>`,
+		"unsupported format":   "data:image/gif;base64,R0lGODlhAQABAAAAACw=",
+		"oversized svg":        "data:image/svg+xml,<svg>" + strings.Repeat(" ", TokenIconSizeLimits[TokenIconSVG]) + "</svg>",
+		"broken png":           "data:image/png;base64,iVBORw0KGgoAAAA=",
+	}
+	for name, icon := range invalids {
+		if _, err := ParseTokenIcon(icon); err == nil {
+			t.Errorf("expect error for %v", name)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package richtypes
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TokenList represents token list in the tokenlists.org json schema
+type TokenList struct {
+	Name      string                  `json:"name"`
+	Timestamp string                  `json:"timestamp"`
+	Version   TokenListVersion        `json:"version"`
+	Tokens    []TokenListItem         `json:"tokens"`
+	LogoURI   string                  `json:"logoURI,omitempty"`
+	Keywords  []string                `json:"keywords,omitempty"`
+	Tags      map[string]TokenListTag `json:"tags,omitempty"`
+}
+
+// TokenListVersion represents semantic version of token list
+type TokenListVersion struct {
+	Major uint `json:"major"`
+	Minor uint `json:"minor"`
+	Patch uint `json:"patch"`
+}
+
+// TokenListTag represents definition of tag used by tokens of token list
+type TokenListTag struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// TokenListItem represents token of token list, the address is in hex or base32 format
+type TokenListItem struct {
+	ChainID    uint32                 `json:"chainId"`
+	Address    string                 `json:"address"`
+	Name       string                 `json:"name"`
+	Symbol     string                 `json:"symbol"`
+	Decimals   uint64                 `json:"decimals"`
+	LogoURI    string                 `json:"logoURI,omitempty"`
+	Tags       []string               `json:"tags,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// Compare returns -1, 0 or 1 if version is older than, same as or newer than other
+func (v TokenListVersion) Compare(other TokenListVersion) int {
+	left := []uint{v.Major, v.Minor, v.Patch}
+	right := []uint{other.Major, other.Minor, other.Patch}
+	for i := range left {
+		if left[i] < right[i] {
+			return -1
+		}
+		if left[i] > right[i] {
+			return 1
+		}
+	}
+	return 0
+}
+
+// String implements the fmt.Stringer interface
+func (v TokenListVersion) String() string {
+	return fmt.Sprintf("%v.%v.%v", v.Major, v.Minor, v.Patch)
+}
+
+// Validate checks token list by the constraints of tokenlists.org json schema
+func (tl *TokenList) Validate() error {
+	if l := len(tl.Name); l == 0 || l > 30 {
+		return fmt.Errorf("length of token list name %q should be in [1, 30]", tl.Name)
+	}
+	if _, err := time.Parse(time.RFC3339, tl.Timestamp); err != nil {
+		return fmt.Errorf("timestamp %q of token list %v is not in RFC3339 format", tl.Timestamp, tl.Name)
+	}
+	if tl.LogoURI != "" && !isValidURI(tl.LogoURI) {
+		return fmt.Errorf("logoURI %q of token list %v is invalid", tl.LogoURI, tl.Name)
+	}
+	if l := len(tl.Tokens); l == 0 || l > 10000 {
+		return fmt.Errorf("count of tokens of token list %v should be in [1, 10000], actual %v", tl.Name, l)
+	}
+
+	keys := make(map[string]struct{}, len(tl.Tokens))
+	for i := range tl.Tokens {
+		item := &tl.Tokens[i]
+		if err := item.Validate(); err != nil {
+			return fmt.Errorf("token %v of token list %v is invalid: %v", i, tl.Name, err)
+		}
+		for _, tag := range item.Tags {
+			if _, ok := tl.Tags[tag]; !ok {
+				return fmt.Errorf("tag %q of token %v is not defined in token list %v", tag, item.Symbol, tl.Name)
+			}
+		}
+
+		address, _ := item.CommonAddress()
+		key := fmt.Sprintf("%v-%v", item.ChainID, address.Hex())
+		if _, ok := keys[key]; ok {
+			return fmt.Errorf("token %v of chain %v is duplicated in token list %v", item.Address, item.ChainID, tl.Name)
+		}
+		keys[key] = struct{}{}
+	}
+	return nil
+}
+
+// Validate checks token by the constraints of tokenlists.org json schema,
+// the network id of base32 address should be same as chain id.
+func (item *TokenListItem) Validate() error {
+	if item.ChainID == 0 {
+		return fmt.Errorf("chainId should be positive")
+	}
+	if _, err := item.CommonAddress(); err != nil {
+		return err
+	}
+	if cfxAddress, err := cfxaddress.NewFromBase32(item.Address); err == nil && cfxAddress.GetNetworkID() != item.ChainID {
+		return fmt.Errorf("network id of address %v is not chainId %v", item.Address, item.ChainID)
+	}
+	if l := len(item.Name); l == 0 || l > 60 {
+		return fmt.Errorf("length of name %q should be in [1, 60]", item.Name)
+	}
+	if l := len(item.Symbol); l == 0 || l > 20 {
+		return fmt.Errorf("length of symbol %q should be in [1, 20]", item.Symbol)
+	}
+	if item.Decimals > 255 {
+		return fmt.Errorf("decimals %v should not be greater than 255", item.Decimals)
+	}
+	if item.LogoURI != "" && !isValidURI(item.LogoURI) {
+		return fmt.Errorf("logoURI %q is invalid", item.LogoURI)
+	}
+	return nil
+}
+
+// CommonAddress returns hex address of token
+func (item *TokenListItem) CommonAddress() (common.Address, error) {
+	if common.IsHexAddress(item.Address) && strings.HasPrefix(strings.ToLower(item.Address), "0x") {
+		return common.HexToAddress(item.Address), nil
+	}
+
+	cfxAddress, err := cfxaddress.NewFromBase32(item.Address)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid address %v, it should be hex or base32 format", item.Address)
+	}
+	return cfxAddress.MustGetCommonAddress(), nil
+}
+
+func isValidURI(uri string) bool {
+	parsed, err := url.Parse(uri)
+	return err == nil && parsed.Scheme != ""
+}
@@ -0,0 +1,46 @@
+package richtypes
+
+import "math/big"
+
+// TokenFlag represents the reason why a token is considered as spam or fake
+type TokenFlag string
+
+const (
+	// TokenFlagDenied means the token is in deny list
+	TokenFlagDenied TokenFlag = "denied"
+	// TokenFlagDuplicateSymbol means the token uses the symbol of a verified token but is not the verified one
+	TokenFlagDuplicateSymbol TokenFlag = "duplicate_symbol"
+	// TokenFlagHugeSupply means the token has zero decimals with huge total supply
+	TokenFlagHugeSupply TokenFlag = "huge_supply"
+	// TokenFlagNoRecord means the token has no record in contract manager
+	TokenFlagNoRecord TokenFlag = "no_record"
+)
+
+// TokenReputation represents whether token is verified and the flags of it
+type TokenReputation struct {
+	Verified bool        `json:"verified"`
+	Flags    []TokenFlag `json:"flags,omitempty"`
+}
+
+// IsFlagged returns true if token has any flag
+func (tr *TokenReputation) IsFlagged() bool {
+	return tr != nil && len(tr.Flags) > 0
+}
+
+// VerifiedToken represents an item of verified token list, the address is in hex or base32 format
+type VerifiedToken struct {
+	Address string `json:"address"`
+	Symbol  string `json:"symbol"`
+	Name    string `json:"name,omitempty"`
+}
+
+// TokenReputationOption represents options of token reputation checker
+type TokenReputationOption struct {
+	// HideFlagged removes flagged tokens from token list and token transfer events
+	HideFlagged bool
+	// HugeSupply is the min total supply flagged for token with zero decimals, DefaultHugeSupply is used if it is nil
+	HugeSupply *big.Int
+}
+
+// DefaultHugeSupply is the default min total supply flagged for token with zero decimals
+var DefaultHugeSupply = new(big.Int).Exp(big.NewInt(10), big.NewInt(12), nil)
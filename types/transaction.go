@@ -43,12 +43,15 @@ type TransactionList struct {
 func (tx *Transaction) ToTokenTransferEvent() *TokenTransferEvent {
 	var tte TokenTransferEvent
 	tte.TransactionHash = tx.Hash
-	// tte.Status = tx.Status
+	tte.Status = tx.Status
 	tte.From = tx.From
 	tte.To = tx.To
 	tte.Value = tx.Value
 	tte.Timestamp = tx.Timestamp
 	tte.BlockHash = tx.BlockHash
+	tte.TransactionIndex = tx.TransactionIndex
+	tte.EpochNumber = tx.EpochNumber
+	tte.GasFee = tx.GasFee
 
 	tte.TokenName = constants.CFXName
 	tte.TokenSymbol = constants.CFXSymbol
@@ -8,6 +8,7 @@ import (
 	"math/big"
 
 	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // TxDictBase is another representation of unsigned transaction which is designed for bitpie wallet
@@ -15,11 +16,16 @@ type TxDictBase struct {
 	Inputs  []TxUnit    `json:"inputs"`
 	Outputs []TxUnit    `json:"outputs"`
 	Extra   TxDictExtra `json:"extra"`
+	// Swap and LiquidityChanges are summaries of DEX operations, the raw transfers are still in Inputs and Outputs
+	Swap             *SwapSummary      `json:"swap,omitempty"`
+	LiquidityChanges []LiquidityChange `json:"liquidity_changes,omitempty"`
 }
 
 type TxDictExtra struct {
 	Gas      *big.Int `json:"gas,omitempty"`
 	GasPrice *big.Int `json:"gas_price,omitempty"`
+	// Label describes the call of AdminControl or SponsorWhitelistControl internal contract
+	Label string `json:"label,omitempty"`
 }
 
 // TxDict is another representation of confirmed transaction which is designed for bitpie wallet
@@ -29,6 +35,14 @@ type TxDict struct {
 	TxAt       JSONTime    `json:"tx_at"`
 	RevertRate *big.Float  `json:"confirmed_at,omitempty"`
 	BlockHash  *types.Hash `json:"block_no,omitempty"`
+	// GasCoveredBySponsor and StorageCoveredBySponsor are got from receipt, which are true if gas fee or storage collateral
+	// is paid by sponsor of contract instead of sender
+	GasCoveredBySponsor     bool `json:"gas_covered_by_sponsor"`
+	StorageCoveredBySponsor bool `json:"storage_covered_by_sponsor"`
+	// GasFee is the gas fee in drip paid for the transaction, which is got from receipt or scan server, it is nil if unknown
+	GasFee *big.Int `json:"gas_fee,omitempty"`
+	// Failed is true if the transaction is executed but failed, in which case no CFX or token is moved except gas fee
+	Failed bool `json:"failed,omitempty"`
 }
 
 // TxUnit represents a transaction unit
@@ -39,4 +53,61 @@ type TxUnit struct {
 	TokenCode       string         `json:"token_code,omitempty"`
 	TokenIdentifier *types.Address `json:"token_identifier"`
 	TokenDecimal    uint64         `json:"token_decimal,omitempty"`
+	// TokenId is the id of non-fungible token, such as erc721
+	TokenId *big.Int `json:"token_id,omitempty"`
+	// ESpaceAddress is the eSpace counterpart of cross-space movement, and Address of the unit is the CrossSpaceCall contract
+	ESpaceAddress *common.Address `json:"espace_address,omitempty"`
+	// EVMAddress and EVMTokenIdentifier are hex addresses of unit converted by ESpaceRichClient,
+	// in which case Address and TokenIdentifier are nil.
+	EVMAddress         *common.Address `json:"evm_address,omitempty"`
+	EVMTokenIdentifier *common.Address `json:"evm_token_identifier,omitempty"`
+	// StakingAction is the operation of staking movement, the Staking contract stands for the staked state in unit pair
+	// of deposit and withdraw, and both units of vote lock are the account as the balance is not changed.
+	StakingAction StakingAction `json:"staking_action,omitempty"`
+	// Kind is mint or burn if tokens are created or destroyed, in which case the Address of input or output is nil
+	// instead of the zero address
+	Kind TxUnitKind `json:"kind,omitempty"`
+}
+
+// GetCommonAddress returns hex address of unit, which is EVMAddress for unit of eSpace, it returns false if there is no address
+func (tu *TxUnit) GetCommonAddress() (common.Address, bool) {
+	if tu.Address != nil {
+		return tu.Address.MustGetCommonAddress(), true
+	}
+	if tu.EVMAddress != nil {
+		return *tu.EVMAddress, true
+	}
+	return common.Address{}, false
+}
+
+// GetTokenCommonAddress returns hex address of token of unit, which is EVMTokenIdentifier for unit of eSpace,
+// it returns the zero address for CFX
+func (tu *TxUnit) GetTokenCommonAddress() common.Address {
+	if tu.TokenIdentifier != nil {
+		return tu.TokenIdentifier.MustGetCommonAddress()
+	}
+	if tu.EVMTokenIdentifier != nil {
+		return *tu.EVMTokenIdentifier
+	}
+	return common.Address{}
+}
+
+// TxUnitKind represents the kind of movement of unit pair, it is empty for transfer
+type TxUnitKind string
+
+const (
+	// TxUnitMint means tokens are created for the output address
+	TxUnitMint TxUnitKind = "mint"
+	// TxUnitBurn means tokens of the input address are destroyed
+	TxUnitBurn TxUnitKind = "burn"
+)
+
+// EpochTxDicts represents TxDicts of an epoch emitted by TxDictStream
+type EpochTxDicts struct {
+	EpochNumber uint64     `json:"epoch_number"`
+	PivotHash   types.Hash `json:"pivot_hash"`
+	TxDicts     []TxDict   `json:"tx_dicts"`
+	// Reverted is true if the epoch with PivotHash is dropped by pivot chain reorg,
+	// the TxDicts emitted before for the epoch should be reverted and TxDicts is empty.
+	Reverted bool `json:"reverted"`
 }
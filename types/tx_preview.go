@@ -0,0 +1,64 @@
+package richtypes
+
+import (
+	"math/big"
+
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+)
+
+// TxWarningType represents the kind of risk found when previewing a transaction
+type TxWarningType string
+
+const (
+	// UnlimitedApprovalWarning means the transaction approves a spender to transfer unlimited tokens of sender
+	UnlimitedApprovalWarning TxWarningType = "UNLIMITED_APPROVAL"
+	// NonTokenContractWarning means the transaction sends cfx or tokens to a contract which is not a token
+	NonTokenContractWarning TxWarningType = "SEND_TO_NON_TOKEN_CONTRACT"
+	// NonPayableValueWarning means the transaction sends cfx to a function which is not payable
+	NonPayableValueWarning TxWarningType = "VALUE_TO_NON_PAYABLE"
+	// PrivilegedActionWarning means the transaction changes admin, destroys contract or changes sponsor whitelist of contract
+	PrivilegedActionWarning TxWarningType = "PRIVILEGED_ACTION"
+)
+
+// TxWarning describes a risk of transaction which should be shown to user before signing
+type TxWarning struct {
+	Type    TxWarningType `json:"type"`
+	Message string        `json:"message"`
+}
+
+// MethodArg describes a decoded argument of contract method
+type MethodArg struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// MethodCall describes decoded method and arguments of transaction data
+type MethodCall struct {
+	Name      string      `json:"name"`
+	Signature string      `json:"signature"`
+	Payable   bool        `json:"payable"`
+	Args      []MethodArg `json:"args"`
+}
+
+// TransferPreview describes a cfx or token movement with amount formatted by token decimals
+type TransferPreview struct {
+	From            *types.Address `json:"from"`
+	To              *types.Address `json:"to"`
+	Amount          string         `json:"amount"`
+	TokenCode       string         `json:"token_code,omitempty"`
+	TokenIdentifier *types.Address `json:"token_identifier,omitempty"`
+	TokenId         *big.Int       `json:"token_id,omitempty"`
+}
+
+// TxPreview is a human readable summary of what an unsigned transaction will do, it is designed for signing screens
+type TxPreview struct {
+	TxDictBase
+	Method    *MethodCall       `json:"method,omitempty"`
+	Transfers []TransferPreview `json:"transfers"`
+	// MaxGasFee is gas * gasPrice in drip
+	MaxGasFee *big.Int `json:"max_gas_fee,omitempty"`
+	// StorageCollateral is the max cfx in drip collateralized for storage limit
+	StorageCollateral *big.Int    `json:"storage_collateral,omitempty"`
+	Warnings          []TxWarning `json:"warnings,omitempty"`
+}
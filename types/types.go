@@ -1,17 +1,62 @@
 package richtypes
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 )
 
-// JSONTime is created for json marshal
+// JSONTime is unix timestamp in seconds created for json marshal
 type JSONTime int64
 
-// MarshalJSON implements interface Marshaler
+// legacyJSONTimeLayout is the zone-less layout in UTC marshaled by previous versions
+const legacyJSONTimeLayout = "2006-01-02T15:04:05"
+
+// NewJSONTime creates JSONTime by time.Time
+func NewJSONTime(t time.Time) JSONTime {
+	return JSONTime(t.Unix())
+}
+
+// Time returns the time in UTC
+func (t JSONTime) Time() time.Time {
+	return time.Unix(int64(t), 0).UTC()
+}
+
+// MarshalJSON implements interface Marshaler, the time is marshaled in RFC3339 format of UTC such as "2020-09-13T12:26:40Z"
 func (t JSONTime) MarshalJSON() ([]byte, error) {
-	_t := time.Unix(int64(t), 0)
-	loc, _ := time.LoadLocation("Greenwich")
-	stamp := fmt.Sprintf("\"%s\"", _t.In(loc).Format("2006-01-02T15:04:05"))
-	return []byte(stamp), nil
+	return json.Marshal(t.Time().Format(time.RFC3339))
+}
+
+// UnmarshalJSON implements interface Unmarshaler, it accepts unix seconds in number or string and RFC3339 string,
+// and the zone-less string marshaled by previous versions is regarded as UTC. The null is ignored.
+func (t *JSONTime) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+
+	var text string
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+	} else {
+		text = string(data)
+	}
+
+	if seconds, err := strconv.ParseInt(text, 10, 64); err == nil {
+		*t = JSONTime(seconds)
+		return nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, text); err == nil {
+		*t = NewJSONTime(parsed)
+		return nil
+	}
+	if parsed, err := time.Parse(legacyJSONTimeLayout, text); err == nil {
+		*t = NewJSONTime(parsed)
+		return nil
+	}
+	return fmt.Errorf("invalid time %v, it should be unix seconds or RFC3339 format", string(data))
 }
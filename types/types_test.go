@@ -0,0 +1,60 @@
+package richtypes
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestJSONTime(t *testing.T) {
+	expect := JSONTime(1600000000)
+
+	marshaled, err := json.Marshal(expect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(marshaled) != `"2020-09-13T12:26:40Z"` {
+		t.Errorf("expect RFC3339 in UTC, actual: %s", marshaled)
+	}
+
+	inputs := []string{
+		string(marshaled),
+		`1600000000`,
+		`"1600000000"`,
+		`"2020-09-13T20:26:40+08:00"`,
+		`"2020-09-13T12:26:40"`,
+	}
+	for _, input := range inputs {
+		var actual JSONTime
+		if err := json.Unmarshal([]byte(input), &actual); err != nil {
+			t.Errorf("unmarshal %v error: %v", input, err)
+			continue
+		}
+		if actual != expect {
+			t.Errorf("expect %v by unmarshal %v, actual: %v", expect, input, actual)
+		}
+	}
+
+	var invalid JSONTime
+	if err := json.Unmarshal([]byte(`"yesterday"`), &invalid); err == nil {
+		t.Errorf("expect error for invalid time")
+	}
+
+	if tm := expect.Time(); tm.Location() != time.UTC || !tm.Equal(time.Unix(1600000000, 0)) || NewJSONTime(tm) != expect {
+		t.Errorf("expect time in UTC convertible back to %v, actual: %v", expect, tm)
+	}
+
+	// TxDict is round-tripped
+	txDict := TxDict{TxAt: expect}
+	data, err := json.Marshal(txDict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded TxDict
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.TxAt != expect {
+		t.Errorf("expect TxAt %v after round trip, actual: %v", expect, decoded.TxAt)
+	}
+}
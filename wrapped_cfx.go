@@ -0,0 +1,135 @@
+package walletsdk
+
+import (
+	"math/big"
+
+	richconstants "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/constants"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/helper"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/constants"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SetWrappedCFXTokens replaces wrapped CFX contracts recognized by converter, which are the well-known
+// wrapped CFX contracts of the network by default.
+func (tc *TxDictConverter) SetWrappedCFXTokens(tokenIdentifiers ...types.Address) {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+
+	tc.wrappedCFXs = make(map[common.Address]struct{}, len(tokenIdentifiers))
+	for _, token := range tokenIdentifiers {
+		tc.wrappedCFXs[token.MustGetCommonAddress()] = struct{}{}
+	}
+}
+
+func (tc *TxDictConverter) setDefaultWrappedCFXTokens() {
+	tc.wrappedCFXs = make(map[common.Address]struct{})
+	for _, address := range richconstants.WrappedCFXHexAddresses[tc.networkID] {
+		tc.wrappedCFXs[address] = struct{}{}
+	}
+}
+
+func (tc *TxDictConverter) isWrappedCFX(address *types.Address) bool {
+	if address == nil {
+		return false
+	}
+
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+	_, ok := tc.wrappedCFXs[address.MustGetCommonAddress()]
+	return ok
+}
+
+// fillTxDictByWrappedCFXEvent fills CFX and wrapped token moved by Deposit, Withdrawal, Minted and Burned events
+// of wrapped CFX, it returns false if log is not one of them.
+func (tc *TxDictConverter) fillTxDictByWrappedCFXEvent(txDictBase *richtypes.TxDictBase, log *types.Log, sn *uint64) (bool, error) {
+	eventParams, err := tc.decoder.DecodeWrappedCFXEvent(log)
+	if err != nil {
+		return false, err
+	}
+
+	switch params := eventParams.(type) {
+	case *richtypes.WrappedCFXDepositEventParams:
+		tc.fillWrapCFX(txDictBase, log.Address, params.To, params.Amount, sn)
+	case *richtypes.WrappedCFXWithdrawalEventParams:
+		tc.fillUnwrapCFX(txDictBase, log.Address, params.From, params.Amount, sn)
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// fillTxDictByWrappedCFXFunction fills CFX and wrapped token moved according to data of unsigned transaction sent to wrapped CFX,
+// it returns false if data is not deposit or withdraw.
+func (tc *TxDictConverter) fillTxDictByWrappedCFXFunction(txDictBase *richtypes.TxDictBase, tx *types.UnsignedTransaction) bool {
+	funcParams, err := tc.decoder.DecodeWrappedCFXFunction(tx.Data)
+	if err != nil || tx.From == nil {
+		return false
+	}
+
+	sn := uint64(len(txDictBase.Inputs))
+	switch params := funcParams.(type) {
+	case *richtypes.WrappedCFXDepositFunctionParams:
+		tc.fillWrapCFX(txDictBase, *tx.To, tx.From.MustGetCommonAddress(), tx.Value.ToInt(), &sn)
+	case *richtypes.WrappedCFXWithdrawFunctionParams:
+		tc.fillUnwrapCFX(txDictBase, *tx.To, tx.From.MustGetCommonAddress(), params.Amount, &sn)
+	default:
+		return false
+	}
+	return true
+}
+
+// fillWrapCFX appends the wrapped token moved from wrapped CFX contract to receiver,
+// the CFX moved to the contract is the value of transaction.
+func (tc *TxDictConverter) fillWrapCFX(txDictBase *richtypes.TxDictBase, wrappedCFX types.Address, receiver common.Address, value *big.Int, sn *uint64) {
+	if value == nil || value.Sign() == 0 {
+		return
+	}
+
+	input := tc.newWrappedCFXUnit(&wrappedCFX, wrappedCFX, value, *sn)
+	output := tc.newWrappedCFXUnit(helper.MustNewCfxAddressPtr(&receiver, tc.networkID), wrappedCFX, value, *sn)
+	(*sn)++
+
+	txDictBase.Inputs = append(txDictBase.Inputs, input)
+	txDictBase.Outputs = append(txDictBase.Outputs, output)
+}
+
+// fillUnwrapCFX appends the wrapped token moved from holder to wrapped CFX contract, and the CFX moved back to holder
+func (tc *TxDictConverter) fillUnwrapCFX(txDictBase *richtypes.TxDictBase, wrappedCFX types.Address, holder common.Address, value *big.Int, sn *uint64) {
+	if value == nil || value.Sign() == 0 {
+		return
+	}
+
+	holderAddress := helper.MustNewCfxAddressPtr(&holder, tc.networkID)
+	tokenInput := tc.newWrappedCFXUnit(holderAddress, wrappedCFX, value, *sn)
+	tokenOutput := tc.newWrappedCFXUnit(&wrappedCFX, wrappedCFX, value, *sn)
+	(*sn)++
+
+	cfxInput := tc.newCFXUnit(&wrappedCFX, value, *sn)
+	cfxOutput := tc.newCFXUnit(holderAddress, value, *sn)
+	(*sn)++
+
+	txDictBase.Inputs = append(txDictBase.Inputs, tokenInput, cfxInput)
+	txDictBase.Outputs = append(txDictBase.Outputs, tokenOutput, cfxOutput)
+}
+
+// newWrappedCFXUnit creates unit of wrapped token, the decimals of wrapped CFX are always the same as CFX
+func (tc *TxDictConverter) newWrappedCFXUnit(address *types.Address, wrappedCFX types.Address, value *big.Int, sn uint64) richtypes.TxUnit {
+	tokenCode := "W" + constants.CFXSymbol
+	tc.mutex.Lock()
+	if token := tc.tokenCache[wrappedCFX.String()]; token != nil && token.TokenSymbol != "" {
+		tokenCode = token.TokenSymbol
+	}
+	tc.mutex.Unlock()
+
+	tokenIdentifier := wrappedCFX
+	return richtypes.TxUnit{
+		Value:           new(big.Int).Set(value),
+		Address:         address,
+		Sn:              sn,
+		TokenCode:       tokenCode,
+		TokenIdentifier: &tokenIdentifier,
+		TokenDecimal:    constants.CFXDecimal,
+	}
+}
@@ -0,0 +1,81 @@
+package walletsdk
+
+import (
+	"math/big"
+	"testing"
+
+	sdk "github.com/Conflux-Chain/go-conflux-sdk"
+	"github.com/Conflux-Chain/go-conflux-sdk-for-wallet/resource/contract/abi"
+	richtypes "github.com/Conflux-Chain/go-conflux-sdk-for-wallet/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types"
+	"github.com/Conflux-Chain/go-conflux-sdk/types/cfxaddress"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestConvertWrappedCFX(t *testing.T) {
+	converter, err := NewTxDictConverter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from := cfxaddress.MustNewFromHex("0x19f4bcf113e0b896d9b34294fd3da86b4adf0302", cfxaddress.NetowrkTypeMainnetID)
+	wcfx := cfxaddress.MustNewFromHex("0x8d7df9316faa0586e175b5e6d03c6bda76e3d950", cfxaddress.NetowrkTypeMainnetID)
+
+	wrappedCFX, err := sdk.NewContract([]byte(abi.GetABI(richtypes.WCFX)), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mintedData, err := wrappedCFX.ABI.Events["Minted"].Inputs.NonIndexed().Pack(big.NewInt(100), []byte{}, []byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// wrap by Minted event
+	txDict := new(richtypes.TxDict)
+	receipt := &types.TransactionReceipt{
+		To: &wcfx,
+		Logs: []types.Log{{
+			Address: wcfx,
+			Topics: []types.Hash{
+				types.Hash(crypto.Keccak256Hash([]byte("Minted(address,address,uint256,bytes,bytes)")).Hex()),
+				types.Hash(common.BytesToHash(from.MustGetCommonAddress().Bytes()).Hex()),
+				types.Hash(common.BytesToHash(from.MustGetCommonAddress().Bytes()).Hex()),
+			},
+			Data: mintedData,
+		}},
+	}
+
+	sn := uint64(1)
+	if err := converter.fillTxDictByTxReceipt(txDict, receipt, &sn); err != nil {
+		t.Fatal(err)
+	}
+	if len(txDict.Outputs) != 1 || txDict.Outputs[0].Address.String() != from.String() ||
+		txDict.Outputs[0].TokenIdentifier.String() != wcfx.String() || txDict.Outputs[0].Value.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("expect wrapped token to %v, actual: %+v", from, txDict.Outputs)
+	}
+
+	// unwrap by withdraw
+	data, err := wrappedCFX.GetData("withdraw", big.NewInt(200))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := new(types.UnsignedTransaction)
+	tx.From = &from
+	tx.To = &wcfx
+	tx.Value = types.NewBigInt(0)
+	tx.Data = data
+
+	txDictBase := converter.ConvertByUnsignedTransaction(tx)
+	if len(txDictBase.Outputs) != 3 {
+		t.Fatalf("expect cfx, token and cfx units, actual: %+v", txDictBase.Outputs)
+	}
+	token, cfx := txDictBase.Outputs[1], txDictBase.Outputs[2]
+	if token.TokenIdentifier == nil || token.Address.String() != wcfx.String() {
+		t.Errorf("expect wrapped token back to %v, actual: %+v", wcfx, token)
+	}
+	if cfx.TokenIdentifier != nil || cfx.Address.String() != from.String() || cfx.Value.Cmp(big.NewInt(200)) != 0 {
+		t.Errorf("expect cfx unwrapped to %v, actual: %+v", from, cfx)
+	}
+}